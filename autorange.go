@@ -0,0 +1,201 @@
+package tsl2591
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Defaults for AutoRange
+const (
+	DefaultAutoRangeLow  = 0.1
+	DefaultAutoRangeHigh = 0.9
+)
+
+// gains lists all gains in increasing order of sensitivity
+var gains = []Gain{GainLow, GainMed, GainHigh, GainMax}
+
+// timings lists all integration times in increasing order of sensitivity
+var timings = []IntegrationTime{
+	IntegrationTime100MS,
+	IntegrationTime200MS,
+	IntegrationTime300MS,
+	IntegrationTime400MS,
+	IntegrationTime500MS,
+	IntegrationTime600MS,
+}
+
+// AutoRange configures the automatic ranging of gain and integration time.
+// Settings are only changed when the dominant channel leaves the band between
+// Low and High, which provides hysteresis. On change, the most sensitive
+// settings are chosen for which the predicted counts lie in the middle of the band.
+type AutoRange struct {
+	// Low is the fraction of full scale below which the sensitivity is increased.
+	// Defaults to DefaultAutoRangeLow if zero.
+	Low float64
+
+	// High is the fraction of full scale above which the sensitivity is decreased.
+	// Defaults to DefaultAutoRangeHigh if zero.
+	High float64
+
+	// Settle is an additional period to wait after changing settings,
+	// on top of waiting for the first valid conversion.
+	Settle time.Duration
+
+	// GainOnly keeps the configured integration time and only adjusts the gain
+	GainOnly bool
+}
+
+// rangeStep is a combination of gain and integration time
+type rangeStep struct {
+	gain   Gain
+	timing IntegrationTime
+}
+
+// sensitivity returns the relative sensitivity of the step
+func (s rangeStep) sensitivity() float64 {
+	return s.gain.Multiplier() * s.timing.Duration().Seconds()
+}
+
+// SetAutoGain enables or disables automatic gain control.
+// This is a shorthand for SetAutoRange with GainOnly set.
+func (tsl *TSL2591) SetAutoGain(enabled bool) {
+	if enabled {
+		tsl.SetAutoRange(&AutoRange{GainOnly: true})
+	} else {
+		tsl.SetAutoRange(nil)
+	}
+}
+
+// SetAutoRange enables automatic ranging with the provided configuration.
+// When enabled, each reading adjusts the gain and integration time when
+// the counts are too low or approaching saturation, re-reading the channels as needed.
+// Provide nil to disable.
+func (tsl *TSL2591) SetAutoRange(autoRange *AutoRange) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if autoRange == nil {
+		tsl.autoRange = nil
+		return
+	}
+	config := *autoRange
+	if config.Low == 0 {
+		config.Low = DefaultAutoRangeLow
+	}
+	if config.High == 0 {
+		config.High = DefaultAutoRangeHigh
+	}
+	tsl.autoRange = &config
+}
+
+// GetAutoRange returns a copy of the auto range configuration or nil if disabled
+func (tsl *TSL2591) GetAutoRange() *AutoRange {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if tsl.autoRange == nil {
+		return nil
+	}
+	config := *tsl.autoRange
+	return &config
+}
+
+// adjustRange steps the settings until the provided counts are within range
+// and returns the counts read with the final settings
+func (tsl *TSL2591) adjustRange(ctx context.Context, c0, c1 uint16) (uint16, uint16, error) {
+	steps := tsl.rangeSteps()
+	for i := 0; i < len(steps); i++ {
+		step, ok := tsl.nextRangeStep(steps, c0, c1)
+		if !ok {
+			return c0, c1, nil
+		}
+
+		tsl.metrics.autoRangeChanges.Add(1)
+		tsl.opts.Logger.Info("TSL2591: auto range changing settings",
+			"chan0", c0, "chan1", c1,
+			"gain", step.gain.String(), "timing", step.timing.String(),
+			"previous_gain", tsl.gain.String(), "previous_timing", tsl.timing.String())
+		if step.gain != tsl.gain {
+			if err := tsl.setGain(step.gain); err != nil {
+				return 0, 0, fmt.Errorf("auto range failed: %w", err)
+			}
+		}
+		if step.timing != tsl.timing {
+			if err := tsl.setTiming(step.timing); err != nil {
+				return 0, 0, fmt.Errorf("auto range failed: %w", err)
+			}
+		}
+		if err := tsl.waitForData(ctx); err != nil {
+			return 0, 0, fmt.Errorf("auto range failed waiting for data: %w", err)
+		}
+		if err := sleepContext(ctx, tsl.autoRange.Settle); err != nil {
+			return 0, 0, err
+		}
+		tsl.metrics.retries.Add(1)
+
+		var err error
+		c0, c1, err = tsl.readChannels(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return c0, c1, nil
+}
+
+// rangeSteps returns the allowed steps in increasing order of sensitivity
+func (tsl *TSL2591) rangeSteps() []rangeStep {
+	if tsl.autoRange.GainOnly {
+		steps := make([]rangeStep, 0, len(gains))
+		for _, gain := range gains {
+			steps = append(steps, rangeStep{gain: gain, timing: tsl.timing})
+		}
+		return steps
+	}
+
+	steps := make([]rangeStep, 0, len(gains)*len(timings))
+	for _, gain := range gains {
+		for _, timing := range timings {
+			steps = append(steps, rangeStep{gain: gain, timing: timing})
+		}
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].sensitivity() < steps[j].sensitivity()
+	})
+	return steps
+}
+
+// nextRangeStep returns the step which should be used for the provided counts,
+// or false if the current settings should be kept
+func (tsl *TSL2591) nextRangeStep(steps []rangeStep, c0, c1 uint16) (rangeStep, bool) {
+	current := rangeStep{gain: tsl.gain, timing: tsl.timing}
+	fullScale := float64(tsl.maxCounts())
+	highest := float64(c0)
+	if c1 > c0 {
+		highest = float64(c1)
+	}
+
+	// Keep settings while within band
+	tooHigh := highest >= tsl.autoRange.High*fullScale
+	tooLow := highest < tsl.autoRange.Low*fullScale
+	if !tooHigh && !tooLow {
+		return rangeStep{}, false
+	}
+
+	// Select the most sensitive step for which the predicted counts
+	// are in the middle of the band. In case of saturation, the
+	// prediction is a lower bound, which might require multiple steps.
+	target := (tsl.autoRange.Low + tsl.autoRange.High) / 2
+	next := steps[0]
+	for _, step := range steps {
+		predicted := highest * step.sensitivity() / current.sensitivity()
+		if predicted <= target*float64(maxCounts(step.timing)) {
+			next = step
+		}
+	}
+	if next == current {
+		return rangeStep{}, false
+	}
+	return next, true
+}
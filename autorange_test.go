@@ -0,0 +1,103 @@
+package tsl2591
+
+import (
+	"testing"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+// restartOps returns the transactions of restarting the ALS cycle after
+// a settings change and waiting for the first valid conversion
+func restartOps() []i2ctest.IO {
+	enable := EnablePowerOn | EnableAEN
+	return []i2ctest.IO{
+		{Addr: Addr, W: []byte{CommandBit | RegisterEnable}, R: []byte{enable}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterEnable, enable &^ EnableAEN}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterEnable, enable}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterDeviceStatus}, R: []byte{StatusAVALID}},
+	}
+}
+
+func TestAutoGainStepsUp(t *testing.T) {
+	// 100 counts at medium gain (25x) is below the band. At high gain (428x),
+	// 1712 counts are predicted, which is the most sensitive gain below the middle
+	// of the band. Max gain would predict 39504 counts.
+	ops := readOps(100, 10, true)
+	ops = append(ops, i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainHigh)}})
+	ops = append(ops, restartOps()...)
+	ops = append(ops, readOps(1712, 171, true)...)
+
+	opts := DefaultOptions()
+	opts.AutoGain = true
+	tsl := newTestSensor(t, opts, ops...)
+
+	m, err := tsl.Measure()
+	if err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	if m.Gain != GainHigh || m.Chan0 != 1712 {
+		t.Errorf("expected chan0 1712 at %v, got %d at %v", GainHigh, m.Chan0, m.Gain)
+	}
+	if changes := tsl.Metrics().AutoRangeChanges; changes != 1 {
+		t.Errorf("expected 1 auto range change, got %d", changes)
+	}
+}
+
+func TestAutoGainStepsDown(t *testing.T) {
+	// A saturated reading only provides a lower bound, so the gain
+	// is stepped down until the counts are within the band
+	ops := readOps(MaxCount100ms, 1000, true)
+	ops = append(ops, i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainLow)}})
+	ops = append(ops, restartOps()...)
+	ops = append(ops, readOps(10000, 400, true)...)
+
+	opts := DefaultOptions()
+	opts.AutoGain = true
+	tsl := newTestSensor(t, opts, ops...)
+
+	m, err := tsl.Measure()
+	if err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	if m.Gain != GainLow || m.Chan0 != 10000 {
+		t.Errorf("expected chan0 10000 at %v, got %d at %v", GainLow, m.Chan0, m.Gain)
+	}
+}
+
+func TestAutoGainKeepsSettingsWithinBand(t *testing.T) {
+	opts := DefaultOptions()
+	opts.AutoGain = true
+	tsl := newTestSensor(t, opts, readOps(10000, 1000, true)...)
+
+	m, err := tsl.Measure()
+	if err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	if m.Gain != GainMed {
+		t.Errorf("expected gain to stay %v, got %v", GainMed, m.Gain)
+	}
+}
+
+func TestAutoRangeStepsTiming(t *testing.T) {
+	// Without GainOnly, the integration time is stepped as well. At 600ms and
+	// high gain, 60 counts at medium gain and 100ms predict 6163 counts.
+	ops := readOps(60, 6, true)
+	ops = append(ops,
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainHigh)}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainHigh) | byte(IntegrationTime600MS)}},
+	)
+	ops = append(ops, restartOps()...)
+	ops = append(ops, readOps(6163, 616, true)...)
+
+	opts := DefaultOptions()
+	opts.AutoRange = &AutoRange{}
+	tsl := newTestSensor(t, opts, ops...)
+
+	m, err := tsl.Measure()
+	if err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	if m.Gain != GainHigh || m.Timing != IntegrationTime600MS {
+		t.Errorf("expected %v and %v, got %v and %v", GainHigh, IntegrationTime600MS, m.Gain, m.Timing)
+	}
+}
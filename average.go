@@ -0,0 +1,214 @@
+package tsl2591
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+// OutlierRejection selects how outliers are rejected by the averaging helpers
+type OutlierRejection byte
+
+const (
+	// OutlierRejectionNone keeps all samples
+	OutlierRejectionNone OutlierRejection = iota
+
+	// OutlierRejectionMAD rejects samples of which the modified z-score,
+	// based on the median absolute deviation (MAD), exceeds the threshold
+	OutlierRejectionMAD
+
+	// OutlierRejectionSigmaClip iteratively rejects samples which deviate
+	// more than threshold standard deviations from the mean
+	OutlierRejectionSigmaClip
+)
+
+// Default thresholds for outlier rejection
+const (
+	DefaultMADThreshold   = 3.5
+	DefaultSigmaThreshold = 3.0
+)
+
+// sigmaClipIterations bounds the number of iterations of sigma clipping
+const sigmaClipIterations = 5
+
+// Average holds the statistics of multiple samples
+type Average struct {
+	// Mean is the arithmetic mean of the samples
+	Mean float64
+
+	// StdDev is the sample standard deviation of the samples
+	StdDev float64
+
+	// Samples is the number of samples used for the statistics
+	Samples int
+
+	// Rejected is the number of samples rejected as outlier, see Opts.OutlierRejection
+	Rejected int
+}
+
+// AverageLux takes n consecutive measurements, spaced by the integration time,
+// and returns the mean and standard deviation of the lux values.
+// The samples bypass the smoothing of Opts.SmoothingAlpha, so the standard
+// deviation reflects the actual spread, and don't affect its state.
+func (tsl *TSL2591) AverageLux(n int) (Average, error) {
+	return tsl.AverageLuxContext(context.Background(), n)
+}
+
+// AverageLuxContext is AverageLux which honors cancellation and deadlines of the context
+func (tsl *TSL2591) AverageLuxContext(ctx context.Context, n int) (Average, error) {
+	measurements, err := tsl.sample(ctx, n)
+	if err != nil {
+		return Average{}, err
+	}
+	values := make([]float64, len(measurements))
+	for i, m := range measurements {
+		values[i] = m.Lux
+	}
+	return tsl.average(values), nil
+}
+
+// AverageRawLuminosity takes n consecutive measurements, spaced by the integration time,
+// and returns the mean and standard deviation of channel 0 and channel 1 respectively
+func (tsl *TSL2591) AverageRawLuminosity(n int) (Average, Average, error) {
+	return tsl.AverageRawLuminosityContext(context.Background(), n)
+}
+
+// AverageRawLuminosityContext is AverageRawLuminosity which honors cancellation and deadlines of the context
+func (tsl *TSL2591) AverageRawLuminosityContext(ctx context.Context, n int) (Average, Average, error) {
+	measurements, err := tsl.sample(ctx, n)
+	if err != nil {
+		return Average{}, Average{}, err
+	}
+	chan0 := make([]float64, len(measurements))
+	chan1 := make([]float64, len(measurements))
+	for i, m := range measurements {
+		chan0[i] = float64(m.Chan0)
+		chan1[i] = float64(m.Chan1)
+	}
+	return tsl.average(chan0), tsl.average(chan1), nil
+}
+
+// sample takes n consecutive unsmoothed measurements, spaced by the integration time
+func (tsl *TSL2591) sample(ctx context.Context, n int) ([]Measurement, error) {
+	if n <= 0 {
+		return nil, errors.New("number of samples must be positive")
+	}
+	measurements := make([]Measurement, 0, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			if err := sleepContext(ctx, measurements[i-1].Timing.Duration()); err != nil {
+				return nil, err
+			}
+		}
+		tsl.mu.Lock()
+		m, err := tsl.measureUnsmoothed(ctx)
+		tsl.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		measurements = append(measurements, m)
+	}
+	return measurements, nil
+}
+
+// average rejects outliers according to the options and
+// calculates the statistics of the remaining values
+func (tsl *TSL2591) average(values []float64) Average {
+	kept := values
+	switch tsl.opts.OutlierRejection {
+	case OutlierRejectionMAD:
+		threshold := tsl.opts.OutlierThreshold
+		if threshold == 0 {
+			threshold = DefaultMADThreshold
+		}
+		kept = rejectMAD(values, threshold)
+	case OutlierRejectionSigmaClip:
+		threshold := tsl.opts.OutlierThreshold
+		if threshold == 0 {
+			threshold = DefaultSigmaThreshold
+		}
+		kept = rejectSigma(values, threshold)
+	}
+	result := average(kept)
+	result.Rejected = len(values) - len(kept)
+	return result
+}
+
+// rejectMAD returns the values of which the modified z-score does not exceed the threshold
+func rejectMAD(values []float64, threshold float64) []float64 {
+	m := median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - m)
+	}
+	mad := median(deviations)
+	if mad == 0 {
+		return values
+	}
+
+	kept := make([]float64, 0, len(values))
+	for _, v := range values {
+		if 0.6745*math.Abs(v-m)/mad <= threshold {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// rejectSigma iteratively returns the values which deviate at most
+// threshold standard deviations from the mean
+func rejectSigma(values []float64, threshold float64) []float64 {
+	kept := values
+	for i := 0; i < sigmaClipIterations; i++ {
+		stats := average(kept)
+		if stats.StdDev == 0 {
+			return kept
+		}
+		next := make([]float64, 0, len(kept))
+		for _, v := range kept {
+			if math.Abs(v-stats.Mean) <= threshold*stats.StdDev {
+				next = append(next, v)
+			}
+		}
+		if len(next) == len(kept) {
+			return kept
+		}
+		kept = next
+	}
+	return kept
+}
+
+// median returns the median of the values without modifying them
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// average calculates the mean and sample standard deviation of the values
+func average(values []float64) Average {
+	result := Average{Samples: len(values)}
+	if len(values) == 0 {
+		return result
+	}
+	for _, v := range values {
+		result.Mean += v
+	}
+	result.Mean /= float64(len(values))
+	if len(values) > 1 {
+		var sumSquares float64
+		for _, v := range values {
+			sumSquares += (v - result.Mean) * (v - result.Mean)
+		}
+		result.StdDev = math.Sqrt(sumSquares / float64(len(values)-1))
+	}
+	return result
+}
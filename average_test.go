@@ -0,0 +1,113 @@
+package tsl2591
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		expected Average
+	}{
+		{name: "empty", values: nil, expected: Average{}},
+		{name: "single", values: []float64{42}, expected: Average{Mean: 42, Samples: 1}},
+		{name: "constant", values: []float64{5, 5, 5}, expected: Average{Mean: 5, Samples: 3}},
+		{name: "sample std dev", values: []float64{2, 4, 4, 4, 5, 5, 7, 9}, expected: Average{Mean: 5, StdDev: math.Sqrt(32.0 / 7), Samples: 8}},
+	}
+	for _, tt := range tests {
+		result := average(tt.values)
+		if result.Samples != tt.expected.Samples ||
+			math.Abs(result.Mean-tt.expected.Mean) > 1e-9 ||
+			math.Abs(result.StdDev-tt.expected.StdDev) > 1e-9 {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestAverageLuxInvalidSamples(t *testing.T) {
+	tsl := newTestSensor(t, nil)
+	for _, n := range []int{0, -1} {
+		if _, err := tsl.AverageLux(n); err == nil {
+			t.Errorf("AverageLux(%d): expected an error", n)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values   []float64
+		expected float64
+	}{
+		{values: nil, expected: 0},
+		{values: []float64{3}, expected: 3},
+		{values: []float64{3, 1, 2}, expected: 2},
+		{values: []float64{4, 1, 3, 2}, expected: 2.5},
+	}
+	for _, tt := range tests {
+		if m := median(tt.values); m != tt.expected {
+			t.Errorf("median(%v): expected %f, got %f", tt.values, tt.expected, m)
+		}
+	}
+}
+
+func TestAverageOutlierRejection(t *testing.T) {
+	spike := []float64{10, 10, 11, 9, 10, 100}
+	flat := make([]float64, 0, 20)
+	for i := 0; i < 19; i++ {
+		flat = append(flat, 10)
+	}
+	flat = append(flat, 100)
+
+	tests := []struct {
+		name      string
+		rejection OutlierRejection
+		threshold float64
+		values    []float64
+		mean      float64
+		rejected  int
+	}{
+		{name: "none", rejection: OutlierRejectionNone, values: spike, mean: 25, rejected: 0},
+		{name: "MAD", rejection: OutlierRejectionMAD, values: spike, mean: 10, rejected: 1},
+		{name: "MAD without deviation", rejection: OutlierRejectionMAD, values: []float64{5, 5, 5, 50}, mean: 16.25, rejected: 0},
+		{name: "sigma clip", rejection: OutlierRejectionSigmaClip, values: flat, mean: 10, rejected: 1},
+		{name: "sigma clip within threshold", rejection: OutlierRejectionSigmaClip, threshold: 5, values: flat, mean: 14.5, rejected: 0},
+	}
+	for _, tt := range tests {
+		tsl := &TSL2591{opts: Opts{OutlierRejection: tt.rejection, OutlierThreshold: tt.threshold}}
+		result := tsl.average(tt.values)
+		if math.Abs(result.Mean-tt.mean) > 1e-9 || result.Rejected != tt.rejected || result.Samples != len(tt.values)-tt.rejected {
+			t.Errorf("%s: expected mean %f with %d rejected, got %+v", tt.name, tt.mean, tt.rejected, result)
+		}
+	}
+}
+
+func TestAverageLuxUnsmoothed(t *testing.T) {
+	opts := DefaultOptions()
+	opts.SmoothingAlpha = 0.5
+	ops := readOps(1000, 100, true)
+	ops = append(ops, readOps(3000, 300, false)...)
+	tsl := newTestSensor(t, opts, ops...)
+
+	low, err := tsl.calculateLux(1000, 100)
+	if err != nil {
+		t.Fatalf("calculateLux failed: %v", err)
+	}
+	high, err := tsl.calculateLux(3000, 300)
+	if err != nil {
+		t.Fatalf("calculateLux failed: %v", err)
+	}
+	expected := average([]float64{low, high})
+
+	result, err := tsl.AverageLux(2)
+	if err != nil {
+		t.Fatalf("AverageLux failed: %v", err)
+	}
+	if math.Abs(result.Mean-expected.Mean) > 1e-9 || math.Abs(result.StdDev-expected.StdDev) > 1e-9 {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+	if v := tsl.smoothing.Value(); v != 0 {
+		t.Errorf("expected samples to bypass the moving average, got state %f", v)
+	}
+}
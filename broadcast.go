@@ -0,0 +1,124 @@
+package tsl2591
+
+import "sync"
+
+// BackpressurePolicy defines what happens when a subscriber does not keep up
+type BackpressurePolicy byte
+
+const (
+	// BackpressureDropNewest drops the new measurement if the subscriber's buffer is full
+	BackpressureDropNewest BackpressurePolicy = iota
+
+	// BackpressureDropOldest drops the oldest buffered measurement to make room for the new one
+	BackpressureDropOldest
+
+	// BackpressureBlock waits until the subscriber has room.
+	// Note a slow subscriber delays all other subscribers.
+	BackpressureBlock
+)
+
+// Broadcaster feeds the measurements of a single source, e.g. SenseContinuous,
+// to multiple subscribers. It is safe for concurrent use.
+type Broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// subscriber is a single subscription of a Broadcaster
+type subscriber struct {
+	measurements chan Measurement
+	policy       BackpressurePolicy
+	done         chan struct{}
+}
+
+// NewBroadcaster returns a broadcaster which forwards all measurements of source
+// to its subscribers. When source is closed, all subscriber channels are closed.
+func NewBroadcaster(source <-chan Measurement) *Broadcaster {
+	b := &Broadcaster{subscribers: make(map[*subscriber]struct{})}
+	go b.run(source)
+	return b
+}
+
+// Subscribe returns a channel receiving all measurements from now on, buffered
+// with the provided size. Call the returned function to unsubscribe,
+// after which the channel is closed.
+func (b *Broadcaster) Subscribe(buffer int, policy BackpressurePolicy) (<-chan Measurement, func()) {
+	sub := &subscriber{
+		measurements: make(chan Measurement, buffer),
+		policy:       policy,
+		done:         make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		close(sub.measurements)
+	} else {
+		b.subscribers[sub] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			// Unblock a pending send before taking the lock
+			close(sub.done)
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subscribers[sub]; ok {
+				delete(b.subscribers, sub)
+				close(sub.measurements)
+			}
+		})
+	}
+	return sub.measurements, unsubscribe
+}
+
+// run forwards measurements from source until it is closed
+func (b *Broadcaster) run(source <-chan Measurement) {
+	for m := range source {
+		b.mu.Lock()
+		for sub := range b.subscribers {
+			sub.send(m)
+		}
+		b.mu.Unlock()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subscribers {
+		close(sub.measurements)
+	}
+	b.subscribers = nil
+}
+
+// send delivers the measurement according to the backpressure policy
+func (s *subscriber) send(m Measurement) {
+	switch s.policy {
+	case BackpressureBlock:
+		select {
+		case s.measurements <- m:
+		case <-s.done:
+		}
+	case BackpressureDropOldest:
+		// An unbuffered channel has nothing to drop
+		for cap(s.measurements) > 0 {
+			select {
+			case s.measurements <- m:
+				return
+			default:
+			}
+			select {
+			case <-s.measurements:
+			default:
+			}
+		}
+		fallthrough
+	default:
+		select {
+		case s.measurements <- m:
+		default:
+		}
+	}
+}
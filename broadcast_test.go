@@ -0,0 +1,88 @@
+package tsl2591
+
+import "testing"
+
+// drain returns the lux values received from the channel until it's closed
+func drain(measurements <-chan Measurement) []float64 {
+	values := []float64{}
+	for m := range measurements {
+		values = append(values, m.Lux)
+	}
+	return values
+}
+
+func TestBroadcasterDropPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		buffer   int
+		policy   BackpressurePolicy
+		expected []float64
+	}{
+		{name: "drop newest", buffer: 2, policy: BackpressureDropNewest, expected: []float64{1, 2}},
+		{name: "drop oldest", buffer: 2, policy: BackpressureDropOldest, expected: []float64{3, 4}},
+		{name: "drop oldest unbuffered", buffer: 0, policy: BackpressureDropOldest, expected: []float64{}},
+	}
+	for _, tt := range tests {
+		source := make(chan Measurement)
+		b := NewBroadcaster(source)
+		measurements, _ := b.Subscribe(tt.buffer, tt.policy)
+
+		// The blocking subscriber is closed once all measurements were broadcast
+		done, _ := b.Subscribe(0, BackpressureBlock)
+		go func() {
+			for i := 1; i <= 4; i++ {
+				source <- Measurement{Lux: float64(i)}
+			}
+			close(source)
+		}()
+		drain(done)
+
+		if values := drain(measurements); !equalValues(values, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, values)
+		}
+	}
+}
+
+func TestBroadcasterBlock(t *testing.T) {
+	source := make(chan Measurement)
+	b := NewBroadcaster(source)
+	measurements, _ := b.Subscribe(0, BackpressureBlock)
+	go func() {
+		for i := 1; i <= 4; i++ {
+			source <- Measurement{Lux: float64(i)}
+		}
+		close(source)
+	}()
+
+	expected := []float64{1, 2, 3, 4}
+	if values := drain(measurements); !equalValues(values, expected) {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+}
+
+func TestBroadcasterUnsubscribe(t *testing.T) {
+	source := make(chan Measurement)
+	defer close(source)
+	b := NewBroadcaster(source)
+
+	measurements, unsubscribe := b.Subscribe(1, BackpressureBlock)
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-measurements; ok {
+		t.Error("expected channel to be closed after unsubscribing")
+	}
+}
+
+func TestBroadcasterSubscribeAfterClose(t *testing.T) {
+	source := make(chan Measurement)
+	b := NewBroadcaster(source)
+	done, _ := b.Subscribe(0, BackpressureBlock)
+	close(source)
+	drain(done)
+
+	measurements, unsubscribe := b.Subscribe(1, BackpressureDropNewest)
+	defer unsubscribe()
+	if _, ok := <-measurements; ok {
+		t.Error("expected channel of closed broadcaster to be closed")
+	}
+}
@@ -0,0 +1,216 @@
+package tsl2591
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DarkOffset holds the dark counts of both channels, normalized to counts/ms/x.
+// The offset is scaled to the current gain and integration time before subtraction.
+type DarkOffset struct {
+	Chan0 float64 `json:"chan0"`
+	Chan1 float64 `json:"chan1"`
+}
+
+// CalibrateDarkOffset takes the provided number of samples, spaced by the integration time,
+// and stores the average counts as dark offset. Cover the sensor completely before calling.
+// The dark offset is subtracted from all subsequent readings.
+func (tsl *TSL2591) CalibrateDarkOffset(samples int) error {
+	return tsl.CalibrateDarkOffsetContext(context.Background(), samples)
+}
+
+// CalibrateDarkOffsetContext is CalibrateDarkOffset which honors cancellation and deadlines of the context
+func (tsl *TSL2591) CalibrateDarkOffsetContext(ctx context.Context, samples int) error {
+	if samples <= 0 {
+		return errors.New("number of samples must be positive")
+	}
+
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if err := tsl.waitForData(ctx); err != nil {
+		return fmt.Errorf("failed waiting for data: %w", err)
+	}
+	var sum0, sum1 float64
+	for i := 0; i < samples; i++ {
+		if i > 0 {
+			if err := sleepContext(ctx, tsl.timing.Duration()); err != nil {
+				return err
+			}
+		}
+		c0, c1, err := tsl.readChannels(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to sample dark counts: %w", err)
+		}
+		sum0 += float64(c0)
+		sum1 += float64(c1)
+	}
+
+	scale := tsl.countScale()
+	tsl.darkOffset = DarkOffset{
+		Chan0: sum0 / float64(samples) / scale,
+		Chan1: sum1 / float64(samples) / scale,
+	}
+	return nil
+}
+
+// SetDarkOffset sets the dark offset, e.g. from a previous calibration.
+// Use the zero value to disable dark offset correction.
+func (tsl *TSL2591) SetDarkOffset(offset DarkOffset) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	tsl.darkOffset = offset
+}
+
+// GetDarkOffset returns the current dark offset
+func (tsl *TSL2591) GetDarkOffset() DarkOffset {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.darkOffset
+}
+
+// countScale returns the product of integration time in milliseconds and the gain multiplier
+func (tsl *TSL2591) countScale() float64 {
+	return float64(tsl.timing.Duration().Milliseconds()) * tsl.gain.Multiplier()
+}
+
+// correctDark subtracts the dark offset, scaled to the current settings, from the raw counts
+func (tsl *TSL2591) correctDark(c0, c1 uint16) (uint16, uint16) {
+	scale := tsl.countScale()
+	return subtractCounts(c0, tsl.darkOffset.Chan0*scale), subtractCounts(c1, tsl.darkOffset.Chan1*scale)
+}
+
+// subtractCounts subtracts the offset from the counts, clamping at zero
+func subtractCounts(counts uint16, offset float64) uint16 {
+	if offset >= float64(counts) {
+		return 0
+	}
+	return counts - uint16(offset+0.5)
+}
+
+// CalibrationPoint pairs a lux value measured by the sensor
+// with the value of a trusted reference meter
+type CalibrationPoint struct {
+	Measured  float64
+	Reference float64
+}
+
+// LuxCalibration is a linear correction applied to the lux value: Scale * lux + Offset.
+// The zero value applies no correction.
+type LuxCalibration struct {
+	Scale  float64 `json:"scale"`
+	Offset float64 `json:"offset"`
+}
+
+// Apply returns the corrected lux value
+func (c LuxCalibration) Apply(lux float64) float64 {
+	if c == (LuxCalibration{}) {
+		return lux
+	}
+	return c.Scale*lux + c.Offset
+}
+
+// FitLuxCalibration fits a scale and offset to the provided points using least squares.
+// At least two points with a different measured value are required.
+func FitLuxCalibration(points ...CalibrationPoint) (LuxCalibration, error) {
+	if len(points) < 2 {
+		return LuxCalibration{}, errors.New("at least two calibration points are required")
+	}
+
+	var sumX, sumY, sumXX, sumXY float64
+	for _, p := range points {
+		sumX += p.Measured
+		sumY += p.Reference
+		sumXX += p.Measured * p.Measured
+		sumXY += p.Measured * p.Reference
+	}
+	n := float64(len(points))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return LuxCalibration{}, errors.New("calibration points must have different measured values")
+	}
+	scale := (n*sumXY - sumX*sumY) / denominator
+	return LuxCalibration{
+		Scale:  scale,
+		Offset: (sumY - scale*sumX) / n,
+	}, nil
+}
+
+// CalibrateLux fits a calibration to the provided points and applies it to all
+// subsequent lux values. See FitLuxCalibration.
+func (tsl *TSL2591) CalibrateLux(points ...CalibrationPoint) error {
+	calibration, err := FitLuxCalibration(points...)
+	if err != nil {
+		return err
+	}
+	tsl.SetLuxCalibration(calibration)
+	return nil
+}
+
+// SetLuxCalibration sets the calibration applied to all lux values, e.g. from a previous fit.
+// Use the zero value to disable the calibration.
+func (tsl *TSL2591) SetLuxCalibration(calibration LuxCalibration) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	tsl.luxCalibration = calibration
+}
+
+// GetLuxCalibration returns the current lux calibration
+func (tsl *TSL2591) GetLuxCalibration() LuxCalibration {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.luxCalibration
+}
+
+// CalibrationProfile holds all calibration data of a sensor,
+// so it can be stored after a field calibration and applied on startup
+type CalibrationProfile struct {
+	DarkOffset     DarkOffset     `json:"dark_offset"`
+	LuxCalibration LuxCalibration `json:"lux_calibration"`
+}
+
+// GetCalibrationProfile returns the current calibration data
+func (tsl *TSL2591) GetCalibrationProfile() CalibrationProfile {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return CalibrationProfile{
+		DarkOffset:     tsl.darkOffset,
+		LuxCalibration: tsl.luxCalibration,
+	}
+}
+
+// SetCalibrationProfile applies the calibration data, see SetDarkOffset and SetLuxCalibration
+func (tsl *TSL2591) SetCalibrationProfile(profile CalibrationProfile) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	tsl.darkOffset = profile.DarkOffset
+	tsl.luxCalibration = profile.LuxCalibration
+}
+
+// SaveCalibrationProfile writes the profile as JSON to the file
+func SaveCalibrationProfile(path string, profile CalibrationProfile) error {
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal calibration profile: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write calibration profile: %w", err)
+	}
+	return nil
+}
+
+// LoadCalibrationProfile reads a profile written by SaveCalibrationProfile
+func LoadCalibrationProfile(path string) (CalibrationProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CalibrationProfile{}, fmt.Errorf("failed to read calibration profile: %w", err)
+	}
+	var profile CalibrationProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return CalibrationProfile{}, fmt.Errorf("failed to parse calibration profile: %w", err)
+	}
+	return profile, nil
+}
@@ -0,0 +1,74 @@
+package tsl2591
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitLuxCalibration(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []CalibrationPoint
+		expected LuxCalibration
+	}{
+		{
+			name:     "identity",
+			points:   []CalibrationPoint{{Measured: 10, Reference: 10}, {Measured: 100, Reference: 100}},
+			expected: LuxCalibration{Scale: 1},
+		},
+		{
+			name:     "two points",
+			points:   []CalibrationPoint{{Measured: 10, Reference: 25}, {Measured: 110, Reference: 225}},
+			expected: LuxCalibration{Scale: 2, Offset: 5},
+		},
+		{
+			name: "least squares",
+			points: []CalibrationPoint{
+				{Measured: 0, Reference: 1},
+				{Measured: 1, Reference: 2},
+				{Measured: 2, Reference: 5},
+			},
+			expected: LuxCalibration{Scale: 2, Offset: 2.0 / 3},
+		},
+	}
+	for _, tt := range tests {
+		calibration, err := FitLuxCalibration(tt.points...)
+		if err != nil {
+			t.Errorf("%s: FitLuxCalibration failed: %v", tt.name, err)
+			continue
+		}
+		if math.Abs(calibration.Scale-tt.expected.Scale) > 1e-9 || math.Abs(calibration.Offset-tt.expected.Offset) > 1e-9 {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.expected, calibration)
+		}
+	}
+}
+
+func TestFitLuxCalibrationInvalid(t *testing.T) {
+	tests := map[string][]CalibrationPoint{
+		"no points":     nil,
+		"single point":  {{Measured: 10, Reference: 12}},
+		"same measured": {{Measured: 10, Reference: 12}, {Measured: 10, Reference: 14}},
+	}
+	for name, points := range tests {
+		if _, err := FitLuxCalibration(points...); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLuxCalibrationApply(t *testing.T) {
+	tests := []struct {
+		calibration LuxCalibration
+		lux         float64
+		expected    float64
+	}{
+		{calibration: LuxCalibration{}, lux: 42, expected: 42},
+		{calibration: LuxCalibration{Scale: 2, Offset: 5}, lux: 10, expected: 25},
+		{calibration: LuxCalibration{Scale: 0.5}, lux: 10, expected: 5},
+	}
+	for _, tt := range tests {
+		if lux := tt.calibration.Apply(tt.lux); lux != tt.expected {
+			t.Errorf("%+v.Apply(%f): expected %f, got %f", tt.calibration, tt.lux, tt.expected, lux)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// runCalibrate walks the user through a field calibration and writes a calibration profile
+func runCalibrate(ctx context.Context, args []string) error {
+	fs := newFlagSet("calibrate")
+	sensor := newSensorFlags(fs)
+	output := fs.String("output", "tsl2591-calibration.json", "File to write the calibration profile to")
+	samples := fs.Int("samples", 10, "Number of samples to average per step")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *samples <= 0 {
+		return errors.New("number of samples must be positive")
+	}
+
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	// Start from scratch, the calibration must be fitted on uncorrected values
+	tsl.SetCalibrationProfile(tsl2591.CalibrationProfile{})
+	lines := readLines(ctx)
+
+	// Dark offset
+	fmt.Println("Step 1: dark offset")
+	fmt.Print("Cover the sensor completely and press Enter...")
+	if _, err := prompt(ctx, lines); err != nil {
+		return err
+	}
+	if err := tsl.CalibrateDarkOffsetContext(ctx, *samples); err != nil {
+		return fmt.Errorf("failed to calibrate dark offset: %w", err)
+	}
+	offset := tsl.GetDarkOffset()
+	fmt.Printf("Dark offset: %g (chan0), %g (chan1) counts/ms/x\n\n", offset.Chan0, offset.Chan1)
+
+	// Reference points
+	fmt.Println("Step 2: reference lux")
+	fmt.Println("Place a reference meter next to the sensor, preferably at different light levels.")
+	var points []tsl2591.CalibrationPoint
+	for {
+		fmt.Printf("Enter the reference lux value for point %d (empty to finish): ", len(points)+1)
+		line, err := prompt(ctx, lines)
+		if err != nil {
+			return err
+		}
+		if line == "" {
+			if len(points) >= 2 {
+				break
+			}
+			fmt.Println("At least two points are required.")
+			continue
+		}
+		reference, err := strconv.ParseFloat(line, 64)
+		if err != nil || reference < 0 {
+			fmt.Printf("Invalid lux value %q\n", line)
+			continue
+		}
+
+		avg, err := tsl.AverageLuxContext(ctx, *samples)
+		if err != nil {
+			return fmt.Errorf("failed to measure lux: %w", err)
+		}
+		fmt.Printf("Measured %.2f lux (std dev %.2f)\n", avg.Mean, avg.StdDev)
+		points = append(points, tsl2591.CalibrationPoint{Measured: avg.Mean, Reference: reference})
+	}
+
+	calibration, err := tsl2591.FitLuxCalibration(points...)
+	if err != nil {
+		return fmt.Errorf("failed to fit lux calibration: %w", err)
+	}
+	fmt.Printf("\nLux calibration: %g * lux + %g\n", calibration.Scale, calibration.Offset)
+
+	profile := tsl2591.CalibrationProfile{DarkOffset: offset, LuxCalibration: calibration}
+	if err := tsl2591.SaveCalibrationProfile(*output, profile); err != nil {
+		return err
+	}
+	fmt.Printf("Calibration profile written to %s, apply it with -calibration %s\n", *output, *output)
+	return nil
+}
+
+// readLines reads stdin line by line in the background
+func readLines(ctx context.Context) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			case lines <- strings.TrimSpace(scanner.Text()):
+			}
+		}
+	}()
+	return lines
+}
+
+// prompt waits for the next line of input
+func prompt(ctx context.Context, lines <-chan string) (string, error) {
+	select {
+	case <-ctx.Done():
+		fmt.Println()
+		return "", ctx.Err()
+	case line, ok := <-lines:
+		if !ok {
+			return "", errors.New("unexpected end of input")
+		}
+		return line, nil
+	}
+}
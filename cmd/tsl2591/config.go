@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// logFile is the log file opened by parseFlags if any, closed on exit
+var logFile io.Closer
+
+// parseFlags parses the flags of a command and applies the values of the config file
+// provided with -config to all flags which weren't set explicitly.
+// Afterwards, the log is redirected to the file provided with -log-file if any.
+//
+// The keys of the config file are flag names. Top-level keys apply to all commands
+// having such flag, keys in a section named after the command only to that command
+// and take precedence. Example:
+//
+//	bus: "1"
+//	gain: med
+//	timing: 200ms
+//	mqtt:
+//	  broker: tcp://localhost:1883
+//	  topic: home/office/lux
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	configFile := fs.String("config", "", "YAML config file, flags override its values")
+	logPath := fs.String("log-file", "", "Write the log to this file instead of stderr")
+	logMaxSize := fs.Int64("log-max-size", 10, "Rotate the log file once it exceeds this size in MB, 0 to disable")
+	logMaxAge := fs.Duration("log-max-age", 0, "Rotate the log file once it is older than this, 0 to disable")
+	logMaxBackups := fs.Int("log-max-backups", 5, "Number of rotated log files to keep, 0 to keep all")
+	_ = fs.Parse(args)
+	if *configFile != "" {
+		if err := applyConfig(fs, *configFile); err != nil {
+			return err
+		}
+	}
+
+	if *logPath != "" {
+		file, err := openRotatingFile(*logPath, *logMaxSize*1024*1024, *logMaxAge, *logMaxBackups)
+		if err != nil {
+			return err
+		}
+		log.SetOutput(file)
+		logFile = file
+	}
+	return nil
+}
+
+// applyConfig sets all flags which weren't set explicitly to the values of the config file
+func applyConfig(fs *flag.FlagSet, configFile string) error {
+	shared, section, err := loadConfig(configFile, strings.TrimPrefix(fs.Name(), "tsl2591 "))
+	if err != nil {
+		return err
+	}
+
+	// Flags set on the command line take precedence
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for name, value := range shared {
+		// Top-level settings might be meant for other commands
+		if set[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("config file: invalid value for %q: %w", name, err)
+		}
+	}
+	for name, value := range section {
+		if set[name] {
+			continue
+		}
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("config file: unknown setting %q for command %s", name, fs.Name())
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("config file: invalid value for %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// loadConfig reads the config file and returns the top-level settings
+// and the settings in the section of the command
+func loadConfig(path, command string) (shared, section map[string]string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var config map[string]any
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	shared = make(map[string]string)
+	section = make(map[string]string)
+	for key, value := range config {
+		settings, isSection := value.(map[string]any)
+		switch {
+		case !isSection:
+			shared[key] = fmt.Sprint(value)
+		case key == command:
+			for name, value := range settings {
+				section[name] = fmt.Sprint(value)
+			}
+		}
+	}
+	return shared, section, nil
+}
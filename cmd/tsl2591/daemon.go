@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// daemonConfig is the runtime configuration exposed on the control socket.
+// All fields are optional when updating.
+type daemonConfig struct {
+	Gain     *tsl2591.Gain            `json:"gain,omitempty"`
+	Timing   *tsl2591.IntegrationTime `json:"timing,omitempty"`
+	AutoGain *bool                    `json:"auto_gain,omitempty"`
+	Low      *uint16                  `json:"low_threshold,omitempty"`
+	High     *uint16                  `json:"high_threshold,omitempty"`
+	Persist  *tsl2591.Persist         `json:"persist,omitempty"`
+}
+
+// runDaemon samples continuously and exposes a control API on a Unix socket:
+//
+//	curl --unix-socket /run/tsl2591.sock http://localhost/measurement
+//	curl --unix-socket /run/tsl2591.sock http://localhost/config
+//	curl --unix-socket /run/tsl2591.sock -d '{"gain": "med", "timing": "200ms"}' http://localhost/config
+func runDaemon(ctx context.Context, args []string) error {
+	fs := newFlagSet("daemon")
+	sensor := newSensorFlags(fs)
+	socket := fs.String("socket", "/run/tsl2591.sock", "Path of the Unix control socket")
+	interval := fs.Duration("interval", DefaultInterval, "Time between two readings, at least the integration time")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *interval < sensor.opts.Timing.Duration() {
+		return fmt.Errorf("interval %s is shorter than the integration time %s", *interval, sensor.opts.Timing.Duration())
+	}
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	// Stop the watchdog before closing the sensor
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go runWatchdog(ctx, tsl)
+
+	measurements, stop := tsl.SenseContinuous(*interval)
+	defer stop()
+	go func() {
+		for m := range measurements {
+			if m.Err != nil {
+				log.Printf("Failed to measure: %v", m.Err)
+			}
+		}
+	}()
+
+	// Remove a stale socket of a previous run
+	if err := os.Remove(*socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", *socket)
+	if err != nil {
+		return fmt.Errorf("failed to listen on socket: %w", err)
+	}
+	if err := os.Chmod(*socket, 0o660); err != nil {
+		_ = listener.Close()
+		return fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/measurement", tsl.HTTPHandler(*interval+time.Second))
+	mux.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost, http.MethodPut:
+			var config daemonConfig
+			if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+				http.Error(w, fmt.Sprintf("invalid config: %v", err), http.StatusBadRequest)
+				return
+			}
+			if err := applyDaemonConfig(tsl, config); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST, PUT")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		config, err := getDaemonConfig(tsl)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(config)
+	})
+
+	log.Printf("Serving control API on %s", *socket)
+	if err := serve(ctx, listener, mux); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve control API: %w", err)
+	}
+	return nil
+}
+
+// getDaemonConfig returns the current configuration of the sensor
+func getDaemonConfig(tsl *tsl2591.TSL2591) (daemonConfig, error) {
+	low, high, err := tsl.GetALSThresholds()
+	if err != nil {
+		return daemonConfig{}, err
+	}
+	persist, err := tsl.GetPersistFilter()
+	if err != nil {
+		return daemonConfig{}, err
+	}
+	gain := tsl.GetGain()
+	timing := tsl.GetTiming()
+	autoGain := tsl.GetAutoRange() != nil
+	return daemonConfig{
+		Gain:     &gain,
+		Timing:   &timing,
+		AutoGain: &autoGain,
+		Low:      &low,
+		High:     &high,
+		Persist:  &persist,
+	}, nil
+}
+
+// applyDaemonConfig applies all provided settings
+func applyDaemonConfig(tsl *tsl2591.TSL2591, config daemonConfig) error {
+	if config.AutoGain != nil {
+		tsl.SetAutoGain(*config.AutoGain)
+	}
+	if config.Gain != nil {
+		if err := tsl.SetGain(*config.Gain); err != nil {
+			return err
+		}
+	}
+	if config.Timing != nil {
+		if err := tsl.SetTiming(*config.Timing); err != nil {
+			return err
+		}
+	}
+	if config.Low != nil || config.High != nil {
+		low, high, err := tsl.GetALSThresholds()
+		if err != nil {
+			return err
+		}
+		if config.Low != nil {
+			low = *config.Low
+		}
+		if config.High != nil {
+			high = *config.High
+		}
+		if err := tsl.SetALSThresholds(low, high); err != nil {
+			return err
+		}
+	}
+	if config.Persist != nil {
+		if err := tsl.SetPersistFilter(*config.Persist); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// register describes a documented register of the sensor
+type register struct {
+	name    string
+	address byte
+
+	// decode describes the value of the register, given the values of all registers
+	decode func(regs map[byte]byte) string
+}
+
+// registers lists all documented registers
+var registers = []register{
+	{"ENABLE", tsl2591.RegisterEnable, decodeEnable},
+	{"CONFIG", tsl2591.RegisterControl, decodeControl},
+	{"AILTL", tsl2591.RegisterThresholdAILTL, nil},
+	{"AILTH", tsl2591.RegisterThresholdAILTH, decodeWord("ALS low threshold", tsl2591.RegisterThresholdAILTL)},
+	{"AIHTL", tsl2591.RegisterThresholdAIHTL, nil},
+	{"AIHTH", tsl2591.RegisterThresholdAIHTH, decodeWord("ALS high threshold", tsl2591.RegisterThresholdAIHTL)},
+	{"NPAILTL", tsl2591.RegisterThresholdNPAILTL, nil},
+	{"NPAILTH", tsl2591.RegisterThresholdNPAILTH, decodeWord("No-persist low threshold", tsl2591.RegisterThresholdNPAILTL)},
+	{"NPAIHTL", tsl2591.RegisterThresholdNPAIHTL, nil},
+	{"NPAIHTH", tsl2591.RegisterThresholdNPAIHTH, decodeWord("No-persist high threshold", tsl2591.RegisterThresholdNPAIHTL)},
+	{"PERSIST", tsl2591.RegisterPersistFilter, decodePersist},
+	{"PID", tsl2591.RegisterPackagePID, decodePackageID},
+	{"ID", tsl2591.RegisterDeviceID, decodeDeviceID},
+	{"STATUS", tsl2591.RegisterDeviceStatus, decodeStatus},
+	{"C0DATAL", tsl2591.RegisterChan0Low, nil},
+	{"C0DATAH", tsl2591.RegisterChan0High, decodeWord("Channel 0 (full spectrum)", tsl2591.RegisterChan0Low)},
+	{"C1DATAL", tsl2591.RegisterChan1Low, nil},
+	{"C1DATAH", tsl2591.RegisterChan1High, decodeWord("Channel 1 (infrared)", tsl2591.RegisterChan1Low)},
+}
+
+// runDump prints all documented registers with their decoded values
+func runDump(_ context.Context, args []string) error {
+	fs := newFlagSet("dump")
+	sensor := newSensorFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	// The sensor is accessed directly, as setting it up would change the registers
+	dev, bus, err := sensor.openDev()
+	if err != nil {
+		return err
+	}
+	defer bus.Close()
+
+	regs, err := tsl2591.DumpRegisters(dev)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tADDRESS\tVALUE\tDECODED")
+	for _, reg := range registers {
+		decoded := ""
+		if reg.decode != nil {
+			decoded = reg.decode(regs)
+		}
+		fmt.Fprintf(w, "%s\t0x%02x\t0x%02x\t%s\n", reg.name, reg.address, regs[reg.address], decoded)
+	}
+	return w.Flush()
+}
+
+// flags returns the names of the set bits
+func flags(value byte, names map[byte]string, order ...byte) string {
+	var set []string
+	for _, bit := range order {
+		if value&bit != 0 {
+			set = append(set, names[bit])
+		}
+	}
+	if len(set) == 0 {
+		return "none"
+	}
+	return strings.Join(set, ", ")
+}
+
+// decodeEnable describes the enabled functions
+func decodeEnable(regs map[byte]byte) string {
+	names := map[byte]string{
+		tsl2591.EnableNPIEN:   "NPIEN",
+		tsl2591.EnableSAI:     "SAI",
+		tsl2591.EnableAIEN:    "AIEN",
+		tsl2591.EnableAEN:     "AEN",
+		tsl2591.EnablePowerOn: "PON",
+	}
+	return flags(regs[tsl2591.RegisterEnable], names,
+		tsl2591.EnableNPIEN, tsl2591.EnableSAI, tsl2591.EnableAIEN, tsl2591.EnableAEN, tsl2591.EnablePowerOn)
+}
+
+// decodeControl describes the gain and integration time
+func decodeControl(regs map[byte]byte) string {
+	value := regs[tsl2591.RegisterControl]
+	gain := tsl2591.Gain(value & 0b00110000)
+	timing := tsl2591.IntegrationTime(value & 0b00000111)
+	return fmt.Sprintf("gain %s, integration time %s", gain, timing)
+}
+
+// decodePersist describes the persist filter
+func decodePersist(regs map[byte]byte) string {
+	return tsl2591.Persist(regs[tsl2591.RegisterPersistFilter] & 0b00001111).String()
+}
+
+// decodePackageID describes the package ID
+func decodePackageID(regs map[byte]byte) string {
+	return fmt.Sprintf("package 0x%02x", regs[tsl2591.RegisterPackagePID]&0b00110000)
+}
+
+// decodeDeviceID verifies the device ID
+func decodeDeviceID(regs map[byte]byte) string {
+	if regs[tsl2591.RegisterDeviceID] == tsl2591.DeviceID {
+		return "TSL2591"
+	}
+	return "unexpected device"
+}
+
+// decodeStatus describes the status flags
+func decodeStatus(regs map[byte]byte) string {
+	names := map[byte]string{
+		tsl2591.StatusNPINTR: "NPINTR",
+		tsl2591.StatusAINT:   "AINT",
+		tsl2591.StatusAVALID: "AVALID",
+	}
+	return flags(regs[tsl2591.RegisterDeviceStatus], names,
+		tsl2591.StatusNPINTR, tsl2591.StatusAINT, tsl2591.StatusAVALID)
+}
+
+// decodeWord returns a decoder for the 16-bit value starting at the low byte
+func decodeWord(name string, low byte) func(regs map[byte]byte) string {
+	return func(regs map[byte]byte) string {
+		return fmt.Sprintf("%s %d", name, uint16(regs[low+1])<<8|uint16(regs[low]))
+	}
+}
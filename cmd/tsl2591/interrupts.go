@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+)
+
+// edgeTimeout bounds a single wait for an interrupt, so the context is checked regularly
+const edgeTimeout = 500 * time.Millisecond
+
+// runInterrupts programs the ALS thresholds and prints each interrupt
+func runInterrupts(ctx context.Context, args []string) error {
+	fs := newFlagSet("interrupts")
+	sensor := newSensorFlags(fs)
+	pinName := fs.String("int-pin", "", "GPIO connected to the INT pin of the sensor, e.g. GPIO17")
+	low := fs.Uint("low", 0, "ALS low threshold in channel 0 counts")
+	high := fs.Uint("high", math.MaxUint16, "ALS high threshold in channel 0 counts")
+	persist := tsl2591.PersistAny
+	fs.Func("persist", "Number of consecutive out of range cycles before an interrupt: every, any, 2, 3, 5, 10, ..., 60 (default any)", func(s string) (err error) {
+		persist, err = tsl2591.ParsePersist(s)
+		return err
+	})
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *pinName == "" {
+		return errors.New("flag -int-pin is required")
+	}
+	if *low > math.MaxUint16 || *high > math.MaxUint16 || *low > *high {
+		return fmt.Errorf("thresholds must satisfy 0 <= low <= high <= %d", math.MaxUint16)
+	}
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	// The sensor opened the host, so the GPIO registry is populated
+	pin := gpioreg.ByName(*pinName)
+	if pin == nil {
+		return fmt.Errorf("unknown GPIO %q", *pinName)
+	}
+	if err := pin.In(gpio.PullUp, gpio.FallingEdge); err != nil {
+		return fmt.Errorf("failed to configure GPIO %s: %w", *pinName, err)
+	}
+
+	if err := tsl.SetALSThresholds(uint16(*low), uint16(*high)); err != nil {
+		return err
+	}
+	if err := tsl.SetPersistFilter(persist); err != nil {
+		return err
+	}
+	if err := tsl.ClearAllInterrupts(); err != nil {
+		return err
+	}
+	if err := tsl.EnableWithOptions(tsl2591.EnableOptions{ALSInterrupt: true}); err != nil {
+		return err
+	}
+	log.Printf("Waiting for interrupts on %s (thresholds %d-%d, persist %s)", pin, *low, *high, persist)
+
+	for {
+		for !pin.WaitForEdge(edgeTimeout) {
+			if ctx.Err() != nil {
+				return nil
+			}
+		}
+
+		status, err := tsl.Status()
+		if err != nil {
+			return err
+		}
+		c0, c1, err := tsl.RawLuminosityContext(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Interrupt: ALS %t, no-persist %t, chan0 %d, chan1 %d",
+			status.ALSInterrupt, status.NoPersistInterrupt, c0, c1)
+		if err := tsl.ClearAllInterrupts(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/JenswBE/golang-tsl2591/mqtt"
+)
+
+// runMQTT publishes readings to an MQTT broker
+func runMQTT(ctx context.Context, args []string) error {
+	fs := newFlagSet("mqtt")
+	sensor := newSensorFlags(fs)
+	var config mqtt.Config
+	fs.StringVar(&config.Broker, "broker", "", "URL of the broker, e.g. tcp://localhost:1883 or ssl://localhost:8883")
+	fs.StringVar(&config.Topic, "topic", "", "Topic to publish the readings on, e.g. home/office/lux")
+	fs.DurationVar(&config.Interval, "interval", mqtt.DefaultInterval, "Time between two readings")
+	qos := fs.Uint("qos", 0, "Quality of service level: 0, 1 or 2")
+	fs.BoolVar(&config.Retain, "retain", false, "Let the broker retain the last reading")
+	fs.StringVar(&config.ClientID, "client-id", mqtt.DefaultClientID, "MQTT client ID")
+	fs.StringVar(&config.Username, "username", "", "Username to authenticate with")
+	fs.StringVar(&config.Password, "password", "", "Password to authenticate with (default $TSL2591_MQTT_PASSWORD)")
+	tlsCA := fs.String("tls-ca", "", "PEM file with the CA certificates to verify the broker")
+	tlsCert := fs.String("tls-cert", "", "PEM file with the client certificate")
+	tlsKey := fs.String("tls-key", "", "PEM file with the client key")
+	tlsInsecure := fs.Bool("tls-insecure", false, "Skip verification of the broker certificate")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if config.Password == "" {
+		// Not used as flag default, as usage would print the secret
+		config.Password = os.Getenv("TSL2591_MQTT_PASSWORD")
+	}
+	if config.Broker == "" || config.Topic == "" {
+		return errors.New("flags -broker and -topic are required")
+	}
+	if *qos > 2 {
+		return fmt.Errorf("invalid QoS %d", *qos)
+	}
+	config.QoS = byte(*qos)
+	if config.Interval < sensor.opts.Timing.Duration() {
+		return fmt.Errorf("interval %s is shorter than the integration time %s", config.Interval, sensor.opts.Timing.Duration())
+	}
+	if *tlsCA != "" || *tlsCert != "" || *tlsInsecure {
+		tlsConfig, err := newTLSConfig(*tlsCA, *tlsCert, *tlsKey, *tlsInsecure)
+		if err != nil {
+			return err
+		}
+		config.TLSConfig = tlsConfig
+	}
+
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	// Stop the watchdog before closing the sensor
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go runWatchdog(ctx, tsl)
+	notifyReady()
+	defer notifyStopping()
+
+	return mqtt.NewPublisher(tsl, config).Run(ctx)
+}
+
+// newTLSConfig creates a TLS config from the provided files
+func newTLSConfig(caFile, certFile, keyFile string, insecure bool) (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: insecure} //nolint:gosec // Explicitly requested by the user
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
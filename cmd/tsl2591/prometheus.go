@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	tslprom "github.com/JenswBE/golang-tsl2591/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// runExportPrometheus serves the sensor metrics for Prometheus
+func runExportPrometheus(ctx context.Context, args []string) error {
+	fs := newFlagSet("export-prometheus")
+	sensor := newSensorFlags(fs)
+	listen := fs.String("listen", ":9591", "Address to listen on")
+	path := fs.String("path", "/metrics", "Path to serve the metrics on")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	// Stop the watchdog before closing the sensor
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go runWatchdog(ctx, tsl)
+
+	registry := prom.NewRegistry()
+	registry.MustRegister(tslprom.NewCollector(tsl))
+	mux := http.NewServeMux()
+	mux.Handle(*path, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	log.Printf("Serving metrics on %s%s", *listen, *path)
+	if err := listenAndServe(ctx, *listen, mux); err != nil {
+		return fmt.Errorf("failed to serve metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// runRead prints readings every interval or once
+func runRead(ctx context.Context, args []string) error {
+	fs := newFlagSet("read")
+	sensor := newSensorFlags(fs)
+	interval := fs.Duration("interval", DefaultInterval, "Time between two readings, at least the integration time")
+	once := fs.Bool("once", false, "Take a single reading, print it to stdout and exit")
+	format := fs.String("template", "", "Go template to print each reading to stdout with, e.g. '{{.Lux}} lx at {{.Timestamp}}'")
+	utc := fs.Bool("utc", false, "Print timestamps in UTC instead of local time")
+	timeFormat := fs.String("time-format", "rfc3339", "Format of the timestamps: rfc3339 or unix")
+	errorHandling := newErrorFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := errorHandling.validate(); err != nil {
+		return err
+	}
+
+	if *interval < sensor.opts.Timing.Duration() {
+		return fmt.Errorf("interval %s is shorter than the integration time %s", *interval, sensor.opts.Timing.Duration())
+	}
+	if *timeFormat != "rfc3339" && *timeFormat != "unix" {
+		return fmt.Errorf("invalid time format %q", *timeFormat)
+	}
+
+	// Every line is prefixed with the timestamp of the reading instead of the time of logging
+	logger := log.New(log.Writer(), "", 0)
+	if *once {
+		logger = log.New(os.Stdout, "", 0)
+	}
+	output := func(m tsl2591.Measurement) error {
+		printMeasurement(logger, formatTimestamp(m.Timestamp, *utc, *timeFormat), m)
+		return nil
+	}
+	if *format != "" {
+		var err error
+		if output, err = newTemplatePrinter(*format); err != nil {
+			return err
+		}
+	}
+
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+
+	if *once {
+		// A single reading must succeed and come from a completed conversion
+		errorHandling.onError = "exit"
+		err := tsl.WaitForData(ctx)
+		if err != nil {
+			err = fmt.Errorf("failed waiting for data: %w", err)
+		} else {
+			err = readAndPrint(ctx, tsl, errorHandling, output)
+		}
+		if closeErr := tsl.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close sensor: %w", closeErr)
+		}
+		return err
+	}
+	defer closeSensor(tsl)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		if err := readAndPrint(ctx, tsl, errorHandling, output); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// readAndPrint reads the sensor and prints the measurement.
+// Failed readings are handled according to the error flags.
+func readAndPrint(ctx context.Context, tsl *tsl2591.TSL2591, errorHandling *errorFlags, output func(tsl2591.Measurement) error) error {
+	m, ok, err := errorHandling.measure(ctx, tsl)
+	if !ok {
+		return err
+	}
+	return output(m)
+}
+
+// printMeasurement prints all values and the effective settings to the logger,
+// each line prefixed with the timestamp
+func printMeasurement(logger *log.Logger, timestamp string, m tsl2591.Measurement) {
+	logger.Printf("%s Total Light: %f lux\n", timestamp, m.Lux)
+	logger.Printf("%s Infrared light: %d\n", timestamp, m.Infrared)
+	logger.Printf("%s Visible light: %d\n", timestamp, m.Visible)
+	logger.Printf("%s Full spectrum (IR + visible) light: %d\n", timestamp, m.FullSpectrum)
+	logger.Printf("%s Raw luminosity: %b (chan0), %b (chan1)\n", timestamp, m.Chan0, m.Chan1)
+	logger.Printf("%s Settings: gain %s, integration time %s\n", timestamp, m.Gain, m.Timing)
+}
+
+// formatTimestamp formats the timestamp as RFC 3339 with milliseconds or as Unix time in seconds
+func formatTimestamp(t time.Time, utc bool, format string) string {
+	if format == "unix" {
+		return fmt.Sprintf("%.3f", float64(t.UnixMilli())/1000)
+	}
+	if utc {
+		t = t.UTC()
+	}
+	return t.Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+// newTemplatePrinter returns a function printing measurements to stdout using the template.
+// A newline is added if the template doesn't end with one.
+func newTemplatePrinter(format string) (func(tsl2591.Measurement) error, error) {
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+	tmpl, err := template.New("output").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return func(m tsl2591.Measurement) error {
+		if err := tmpl.Execute(os.Stdout, m); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		return nil
+	}, nil
+}
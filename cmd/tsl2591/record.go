@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	_ "modernc.org/sqlite" // Registers the sqlite driver
+)
+
+// recordSchema creates the measurements table if it doesn't exist
+const recordSchema = `
+CREATE TABLE IF NOT EXISTS measurements (
+	timestamp     INTEGER NOT NULL, -- Unix time in milliseconds
+	lux           REAL    NOT NULL,
+	visible       INTEGER NOT NULL,
+	infrared      INTEGER NOT NULL,
+	full_spectrum INTEGER NOT NULL,
+	chan0         INTEGER NOT NULL,
+	chan1         INTEGER NOT NULL,
+	gain          INTEGER NOT NULL,
+	timing        INTEGER NOT NULL,
+	saturated     INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS measurements_timestamp ON measurements (timestamp);
+`
+
+// runRecord records measurements into a SQLite database or exports them
+func runRecord(ctx context.Context, args []string) error {
+	fs := newFlagSet("record")
+	sensor := newSensorFlags(fs)
+	dbPath := fs.String("db", "tsl2591.sqlite", "Path of the SQLite database")
+	interval := fs.Duration("interval", DefaultInterval, "Time between two readings, at least the integration time")
+	export := fs.String("export", "", "Export the recorded measurements to stdout instead of recording: csv or json")
+	since := fs.Duration("since", 0, "Only export measurements of this last period, 0 for all")
+	errorHandling := newErrorFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := errorHandling.validate(); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", *dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, recordSchema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	switch *export {
+	case "":
+	case "csv", "json":
+		var from time.Time
+		if *since > 0 {
+			from = time.Now().Add(-*since)
+		}
+		return exportMeasurements(ctx, db, *export, from)
+	default:
+		return fmt.Errorf("invalid export format %q", *export)
+	}
+
+	if *interval < sensor.opts.Timing.Duration() {
+		return fmt.Errorf("interval %s is shorter than the integration time %s", *interval, sensor.opts.Timing.Duration())
+	}
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	log.Printf("Recording measurements into %s", *dbPath)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		m, ok, err := errorHandling.measure(ctx, tsl)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if err := insertMeasurement(ctx, db, m); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// insertMeasurement stores a single measurement
+func insertMeasurement(ctx context.Context, db *sql.DB, m tsl2591.Measurement) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO measurements (timestamp, lux, visible, infrared, full_spectrum, chan0, chan1, gain, timing, saturated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.Timestamp.UnixMilli(), m.Lux, m.Visible, m.Infrared, m.FullSpectrum,
+		m.Chan0, m.Chan1, byte(m.Gain), byte(m.Timing), m.Saturated)
+	if err != nil {
+		return fmt.Errorf("failed to insert measurement: %w", err)
+	}
+	return nil
+}
+
+// exportMeasurements writes all measurements since from to stdout in the format
+func exportMeasurements(ctx context.Context, db *sql.DB, format string, from time.Time) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT timestamp, lux, visible, infrared, full_spectrum, chan0, chan1, gain, timing, saturated
+		FROM measurements WHERE timestamp >= ? ORDER BY timestamp`, from.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to query measurements: %w", err)
+	}
+	defer rows.Close()
+
+	csvWriter := csv.NewWriter(os.Stdout)
+	jsonEncoder := json.NewEncoder(os.Stdout)
+	if format == "csv" {
+		_ = csvWriter.Write([]string{"timestamp", "lux", "visible", "infrared", "full_spectrum", "chan0", "chan1", "gain", "timing", "saturated"})
+	}
+	for rows.Next() {
+		var m tsl2591.Measurement
+		var millis int64
+		var gain, timing byte
+		if err := rows.Scan(&millis, &m.Lux, &m.Visible, &m.Infrared, &m.FullSpectrum,
+			&m.Chan0, &m.Chan1, &gain, &timing, &m.Saturated); err != nil {
+			return fmt.Errorf("failed to read measurement: %w", err)
+		}
+		m.Timestamp = time.UnixMilli(millis)
+		m.Gain = tsl2591.Gain(gain)
+		m.Timing = tsl2591.IntegrationTime(timing)
+
+		if format == "json" {
+			if err := jsonEncoder.Encode(m); err != nil {
+				return fmt.Errorf("failed to write measurement: %w", err)
+			}
+			continue
+		}
+		_ = csvWriter.Write([]string{
+			m.Timestamp.Format(time.RFC3339Nano),
+			strconv.FormatFloat(m.Lux, 'f', -1, 64),
+			strconv.FormatUint(uint64(m.Visible), 10),
+			strconv.FormatUint(uint64(m.Infrared), 10),
+			strconv.FormatUint(uint64(m.FullSpectrum), 10),
+			strconv.FormatUint(uint64(m.Chan0), 10),
+			strconv.FormatUint(uint64(m.Chan1), 10),
+			m.Gain.String(),
+			m.Timing.String(),
+			strconv.FormatBool(m.Saturated),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read measurements: %w", err)
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// runReg reads or writes a single register
+func runReg(ctx context.Context, args []string) error {
+	fs := newFlagSet("reg")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: tsl2591 reg [flags] read <address>")
+		fmt.Fprintln(fs.Output(), "       tsl2591 reg [flags] write <address> <value>")
+		fs.PrintDefaults()
+	}
+	sensor := newSensorFlags(fs)
+	yes := fs.Bool("yes", false, "Don't ask for confirmation of dangerous writes")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var write bool
+	switch {
+	case fs.NArg() == 2 && fs.Arg(0) == "read":
+	case fs.NArg() == 3 && fs.Arg(0) == "write":
+		write = true
+	default:
+		fs.Usage()
+		return exitCodeError{code: 2}
+	}
+	address, err := parseByte(fs.Arg(1))
+	if err != nil || address > 0b00011111 {
+		return fmt.Errorf("invalid register address %q", fs.Arg(1))
+	}
+	var value byte
+	if write {
+		if value, err = parseByte(fs.Arg(2)); err != nil {
+			return fmt.Errorf("invalid value %q", fs.Arg(2))
+		}
+		if warning := dangerousWrite(address, value); warning != "" && !*yes {
+			fmt.Printf("Warning: %s. Continue? [y/N] ", warning)
+			answer, err := prompt(ctx, readLines(ctx))
+			if err != nil {
+				return err
+			}
+			if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+				return errors.New("aborted")
+			}
+		}
+	}
+
+	// The sensor is accessed directly, as setting it up would change the registers
+	dev, bus, err := sensor.openDev()
+	if err != nil {
+		return err
+	}
+	defer bus.Close()
+
+	if write {
+		if err := dev.Tx([]byte{tsl2591.CommandBit | address, value}, nil); err != nil {
+			return fmt.Errorf("failed to write register 0x%02x: %w", address, err)
+		}
+		return nil
+	}
+	buf := make([]byte, 1)
+	if err := dev.Tx([]byte{tsl2591.CommandBit | address}, buf); err != nil {
+		return fmt.Errorf("failed to read register 0x%02x: %w", address, err)
+	}
+	fmt.Printf("0x%02x\n", buf[0])
+	return nil
+}
+
+// parseByte parses a decimal, hexadecimal (0x), octal (0o) or binary (0b) byte
+func parseByte(s string) (byte, error) {
+	value, err := strconv.ParseUint(s, 0, 8)
+	return byte(value), err
+}
+
+// dangerousWrite returns a warning if writing the value to the register
+// might disturb the sensor, or an empty string if the write is harmless
+func dangerousWrite(address, value byte) string {
+	switch {
+	case address == tsl2591.RegisterControl && value&tsl2591.ControlSRESET != 0:
+		return "this resets the sensor"
+	case address == tsl2591.RegisterEnable && value&tsl2591.EnablePowerOn == 0:
+		return "this powers off the sensor"
+	case address > tsl2591.RegisterPersistFilter || address == 0x02 || address == 0x03:
+		return fmt.Sprintf("register 0x%02x is read-only or reserved", address)
+	default:
+		return ""
+	}
+}
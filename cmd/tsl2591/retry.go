@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// errorFlags holds the flags controlling how failed readings are handled
+type errorFlags struct {
+	maxRetries int
+	onError    string
+}
+
+// newErrorFlags registers the error handling flags on the flag set
+func newErrorFlags(fs *flag.FlagSet) *errorFlags {
+	f := &errorFlags{}
+	fs.IntVar(&f.maxRetries, "max-retries", 3, "Number of times a failed reading is retried")
+	fs.StringVar(&f.onError, "on-error", "continue", "What to do when a reading failed after all retries: continue or exit")
+	return f
+}
+
+// validate checks the parsed flags
+func (f *errorFlags) validate() error {
+	if f.maxRetries < 0 {
+		return errors.New("max retries must not be negative")
+	}
+	if f.onError != "continue" && f.onError != "exit" {
+		return fmt.Errorf("invalid value %q for -on-error", f.onError)
+	}
+	return nil
+}
+
+// measure takes a measurement, retrying failed readings after an integration cycle.
+// If all attempts failed, ok is false and err is only set if the command should exit.
+func (f *errorFlags) measure(ctx context.Context, tsl *tsl2591.TSL2591) (m tsl2591.Measurement, ok bool, err error) {
+	for attempt := 0; ; attempt++ {
+		m, err = tsl.MeasureContext(ctx)
+		if err == nil {
+			return m, true, nil
+		}
+		if ctx.Err() != nil {
+			return m, false, ctx.Err()
+		}
+		if attempt == f.maxRetries {
+			break
+		}
+		log.Printf("Reading failed, retrying (%d/%d): %v", attempt+1, f.maxRetries, err)
+		select {
+		case <-ctx.Done():
+			return m, false, ctx.Err()
+		case <-time.After(tsl.GetTiming().Duration()):
+		}
+	}
+
+	if f.onError == "exit" {
+		return m, false, fmt.Errorf("failed to measure: %w", err)
+	}
+	log.Printf("Reading failed, skipping: %v", err)
+	return m, false, nil
+}
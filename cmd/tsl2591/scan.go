@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/host/v3"
+)
+
+// runScan probes all I2C buses for a TSL2591
+func runScan(_ context.Context, args []string) error {
+	fs := newFlagSet("scan")
+	address := fs.String("address", "0x29", "I2C address to probe")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	addr, err := strconv.ParseUint(*address, 0, 16)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", *address, err)
+	}
+
+	if _, err := host.Init(); err != nil {
+		return fmt.Errorf("unable to init host: %w", err)
+	}
+	refs := i2creg.All()
+	if len(refs) == 0 {
+		return errors.New("no I2C buses found, is I2C enabled?")
+	}
+
+	found := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BUS\tALIASES\tRESULT")
+	for _, ref := range refs {
+		result := probe(ref, uint16(addr))
+		if result == "TSL2591 found" {
+			found++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", ref.Name, strings.Join(ref.Aliases, ", "), result)
+	}
+	_ = w.Flush()
+
+	if found == 0 {
+		return fmt.Errorf("no TSL2591 found at address 0x%02x", addr)
+	}
+	return nil
+}
+
+// probe reads the device ID at the address on the bus and describes the result
+func probe(ref *i2creg.Ref, addr uint16) string {
+	bus, err := ref.Open()
+	if err != nil {
+		return fmt.Sprintf("failed to open bus: %v", err)
+	}
+	defer bus.Close()
+
+	dev := &i2c.Dev{Addr: addr, Bus: bus}
+	id := make([]byte, 1)
+	if err := dev.Tx([]byte{tsl2591.CommandBit | tsl2591.RegisterDeviceID}, id); err != nil {
+		return "no device"
+	}
+	if id[0] != tsl2591.DeviceID {
+		return fmt.Sprintf("unexpected device ID 0x%02x", id[0])
+	}
+	return "TSL2591 found"
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// selftestResult is the result of a single self-test step
+type selftestResult struct {
+	name    string
+	err     error
+	details string
+}
+
+// runSelftest verifies the sensor and prints a pass/fail report
+func runSelftest(ctx context.Context, args []string) error {
+	fs := newFlagSet("selftest")
+	sensor := newSensorFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	// Auto ranging would interfere with testing the individual settings
+	sensor.opts.AutoGain = false
+	sensor.opts.AutoRange = nil
+
+	dev, bus, err := sensor.openDev()
+	if err != nil {
+		return err
+	}
+	defer bus.Close()
+
+	var results []selftestResult
+	tsl, err := tsl2591.NewTSL2591WithConn(dev, sensor.opts)
+	results = append(results, selftestResult{name: "Set up and device ID", err: err})
+	if err == nil {
+		defer closeSensor(tsl)
+		results = append(results, selftestPackageID(tsl))
+		results = append(results, selftestSettings(ctx, tsl)...)
+		results = append(results, selftestInterrupt(tsl))
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TEST\tRESULT\tDETAILS")
+	for _, r := range results {
+		result, details := "PASS", r.details
+		if r.err != nil {
+			result, details = "FAIL", r.err.Error()
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.name, result, details)
+	}
+	_ = w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", failed, len(results))
+	}
+	return nil
+}
+
+// selftestPackageID verifies the package ID
+func selftestPackageID(tsl *tsl2591.TSL2591) selftestResult {
+	result := selftestResult{name: "Package ID"}
+	pid, err := tsl.PackageID()
+	if err != nil {
+		result.err = err
+		return result
+	}
+	if pid != 0 {
+		result.err = fmt.Errorf("unexpected package ID 0x%02x", pid)
+		return result
+	}
+	result.details = "0x00"
+	return result
+}
+
+// selftestSettings takes a reading with each gain and integration time
+func selftestSettings(ctx context.Context, tsl *tsl2591.TSL2591) []selftestResult {
+	gains := []tsl2591.Gain{tsl2591.GainLow, tsl2591.GainMed, tsl2591.GainHigh, tsl2591.GainMax}
+	timings := []tsl2591.IntegrationTime{
+		tsl2591.IntegrationTime100MS, tsl2591.IntegrationTime200MS, tsl2591.IntegrationTime300MS,
+		tsl2591.IntegrationTime400MS, tsl2591.IntegrationTime500MS, tsl2591.IntegrationTime600MS,
+	}
+	results := make([]selftestResult, 0, len(gains)*len(timings))
+	for _, gain := range gains {
+		for _, timing := range timings {
+			result := selftestResult{name: fmt.Sprintf("Reading at %s, %s", gain, timing)}
+			result.details, result.err = selftestReading(ctx, tsl, gain, timing)
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// selftestReading takes a single reading with the provided settings
+func selftestReading(ctx context.Context, tsl *tsl2591.TSL2591, gain tsl2591.Gain, timing tsl2591.IntegrationTime) (string, error) {
+	if err := tsl.SetGain(gain); err != nil {
+		return "", err
+	}
+	if err := tsl.SetTiming(timing); err != nil {
+		return "", err
+	}
+	if err := tsl.WaitForData(ctx); err != nil {
+		return "", err
+	}
+	c0, c1, err := tsl.RawLuminosityContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("chan0 %d, chan1 %d", c0, c1), nil
+}
+
+// selftestInterrupt forces an interrupt and verifies it can be cleared
+func selftestInterrupt(tsl *tsl2591.TSL2591) selftestResult {
+	result := selftestResult{name: "Force and clear interrupt"}
+	if err := tsl.ForceInterrupt(); err != nil {
+		result.err = err
+		return result
+	}
+	status, err := tsl.Status()
+	if err != nil {
+		result.err = err
+		return result
+	}
+	if !status.ALSInterrupt && !status.NoPersistInterrupt {
+		result.err = errors.New("interrupt not asserted after forcing it")
+		return result
+	}
+	if err := tsl.ClearAllInterrupts(); err != nil {
+		result.err = err
+		return result
+	}
+	if status, err = tsl.Status(); err != nil {
+		result.err = err
+		return result
+	}
+	if status.ALSInterrupt || status.NoPersistInterrupt {
+		result.err = errors.New("interrupt still asserted after clearing it")
+	}
+	return result
+}
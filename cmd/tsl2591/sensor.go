@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/host/v3"
+)
+
+// sensorFlags holds the flags shared by all commands to set up the sensor
+type sensorFlags struct {
+	opts        *tsl2591.Opts
+	calibration string
+}
+
+// newSensorFlags registers the sensor flags on the flag set
+func newSensorFlags(fs *flag.FlagSet) *sensorFlags {
+	f := &sensorFlags{opts: tsl2591.DefaultOptions()}
+	fs.StringVar(&f.opts.Bus, "bus", "", "Name of the bus")
+	fs.Func("address", fmt.Sprintf("I2C address of the sensor (default 0x%02x)", f.opts.Address), func(s string) error {
+		addr, err := strconv.ParseUint(s, 0, 16)
+		f.opts.Address = uint16(addr)
+		return err
+	})
+	gainName := strings.ToLower(strings.TrimPrefix(strings.Fields(f.opts.Gain.String())[0], "Gain"))
+	fs.Func("gain", fmt.Sprintf("Gain: low, med, high or max (default %s)", gainName), func(s string) (err error) {
+		f.opts.Gain, err = tsl2591.ParseGain(s)
+		return err
+	})
+	fs.Func("timing", fmt.Sprintf("Integration time: 100ms to 600ms in steps of 100ms (default %s)", f.opts.Timing), func(s string) (err error) {
+		f.opts.Timing, err = tsl2591.ParseIntegrationTime(s)
+		return err
+	})
+	fs.BoolFunc("auto-gain", "Adjust the gain and integration time automatically to the light level", func(s string) error {
+		enabled, err := strconv.ParseBool(s)
+		f.opts.AutoRange = nil
+		if enabled {
+			f.opts.AutoRange = &tsl2591.AutoRange{}
+		}
+		return err
+	})
+	fs.StringVar(&f.calibration, "calibration", "", "Calibration profile to apply, see the calibrate command")
+	return f
+}
+
+// closeSensor closes the sensor and logs any error, for use with defer
+func closeSensor(tsl *tsl2591.TSL2591) {
+	if err := tsl.Close(); err != nil {
+		log.Printf("Failed to close sensor: %v", err)
+	}
+}
+
+// open sets up the sensor with the parsed flags
+func (f *sensorFlags) open() (*tsl2591.TSL2591, error) {
+	var profile tsl2591.CalibrationProfile
+	if f.calibration != "" {
+		var err error
+		if profile, err = tsl2591.LoadCalibrationProfile(f.calibration); err != nil {
+			return nil, err
+		}
+	}
+	tsl, err := tsl2591.NewTSL2591(f.opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up sensor: %w", err)
+	}
+	tsl.SetCalibrationProfile(profile)
+	return tsl, nil
+}
+
+// openDev opens the bus and returns the raw device at the configured address,
+// e.g. to access registers which aren't exposed by the driver.
+// The returned bus must be closed by the caller.
+func (f *sensorFlags) openDev() (*i2c.Dev, i2c.BusCloser, error) {
+	if _, err := host.Init(); err != nil {
+		return nil, nil, fmt.Errorf("unable to init host: %w", err)
+	}
+	bus, err := i2creg.Open(f.opts.Bus)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open I2C bus: %w", err)
+	}
+	addr := f.opts.Address
+	if addr == 0 {
+		addr = tsl2591.Addr
+	}
+	return &i2c.Dev{Addr: addr, Bus: bus}, bus, nil
+}
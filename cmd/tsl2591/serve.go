@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	"github.com/JenswBE/golang-tsl2591/websocket"
+)
+
+// shutdownTimeout is the maximum time to wait for open requests on shutdown
+const shutdownTimeout = 5 * time.Second
+
+// runServe serves the readings over HTTP
+func runServe(ctx context.Context, args []string) error {
+	fs := newFlagSet("serve")
+	sensor := newSensorFlags(fs)
+	listen := fs.String("listen", ":8080", "Address to listen on")
+	maxAge := fs.Duration("max-age", time.Second, "Maximum age of the reading served on /measurement")
+	interval := fs.Duration("interval", DefaultInterval, "Time between two readings on /stream")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *interval < sensor.opts.Timing.Duration() {
+		return fmt.Errorf("interval %s is shorter than the integration time %s", *interval, sensor.opts.Timing.Duration())
+	}
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	// Stop the watchdog before closing the sensor
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go runWatchdog(ctx, tsl)
+
+	measurements, stop := tsl.SenseContinuous(*interval)
+	defer stop()
+	broadcaster := tsl2591.NewBroadcaster(measurements)
+
+	mux := http.NewServeMux()
+	mux.Handle("/measurement", tsl.HTTPHandler(*maxAge))
+	mux.Handle("/stream", websocket.NewHandler(broadcaster))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		if err := tsl.Healthy(); err != nil {
+			http.Error(w, fmt.Sprintf("sensor unhealthy: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+
+	log.Printf("Serving /measurement, /stream and /healthz on %s", *listen)
+	if err := listenAndServe(ctx, *listen, mux); err != nil {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+// listenAndServe serves HTTP until the context is done, after which the server is shut down gracefully.
+// Systemd is notified once listening and when shutting down.
+func listenAndServe(ctx context.Context, addr string, handler http.Handler) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, listener, handler)
+}
+
+// serve serves HTTP on the listener until the context is done, see listenAndServe
+func serve(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+	notifyReady()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	notifyStopping()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// sdNotify sends a state like "READY=1" to systemd.
+// This is a no-op if not started by systemd with Type=notify.
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+	if socket[0] == '@' {
+		// Abstract socket
+		socket = "\x00" + socket[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("failed to connect to systemd: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return fmt.Errorf("failed to notify systemd: %w", err)
+	}
+	return nil
+}
+
+// notifyReady tells systemd the service is ready and logs any failure
+func notifyReady() {
+	if err := sdNotify("READY=1"); err != nil {
+		log.Printf("Failed to notify readiness: %v", err)
+	}
+}
+
+// notifyStopping tells systemd the service is stopping and logs any failure
+func notifyStopping() {
+	if err := sdNotify("STOPPING=1"); err != nil {
+		log.Printf("Failed to notify stopping: %v", err)
+	}
+}
+
+// watchdogInterval returns the interval to ping the systemd watchdog
+// or zero if the watchdog is not enabled for this process
+func watchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+	// Ping twice per timeout as recommended by systemd
+	return time.Duration(usec) * time.Microsecond / 2
+}
+
+// runWatchdog pings the systemd watchdog as long as the sensor responds, until the context is done.
+// An unresponsive sensor lets the watchdog expire, so systemd restarts the service.
+func runWatchdog(ctx context.Context, tsl *tsl2591.TSL2591) {
+	interval := watchdogInterval()
+	if interval == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := tsl.Healthy(); err != nil {
+			log.Printf("Sensor unhealthy, skipping watchdog ping: %v", err)
+			continue
+		}
+		if err := sdNotify("WATCHDOG=1"); err != nil {
+			log.Printf("Failed to ping watchdog: %v", err)
+		}
+	}
+}
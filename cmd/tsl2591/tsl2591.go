@@ -1,66 +1,119 @@
 // tsl2591 - A command for interacting with TSL2591 lux sensors.
+//
+// Usage:
+//
+//	tsl2591 [command] [flags]
+//
+// Run "tsl2591 help" for the list of commands.
+// The read command is used if no command is provided.
 
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
-
-	tsl2591 "github.com/JenswBE/golang-tsl2591"
 )
 
-const Interval = 1 * time.Second
-
-func main() {
-	bus := flag.String("bus", "", "Name of the bus")
-	flag.Parse()
+// DefaultInterval is the default time between two readings
+const DefaultInterval = 1 * time.Second
 
-	opts := tsl2591.DefaultOptions()
-	opts.Bus = *bus
-	tsl, err := tsl2591.NewTSL2591(opts)
-	if err != nil {
-		log.Panic(err)
-	}
-	defer func() {
-		if disableErr := tsl.Disable(); disableErr != nil {
-			log.Panic(err)
-		}
-	}()
+// command is a subcommand of the CLI
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
 
-	ticker := time.NewTicker(Interval)
+// commands lists all subcommands. The first one is the default.
+var commands = []command{
+	{name: "read", usage: "Print readings every interval or once", run: runRead},
+	{name: "export-prometheus", usage: "Serve Prometheus metrics", run: runExportPrometheus},
+	{name: "mqtt", usage: "Publish readings to an MQTT broker", run: runMQTT},
+	{name: "serve", usage: "Serve readings over HTTP", run: runServe},
+	{name: "scan", usage: "Probe all I2C buses for a sensor", run: runScan},
+	{name: "selftest", usage: "Verify the sensor and print a pass/fail report", run: runSelftest},
+	{name: "calibrate", usage: "Calibrate the sensor interactively", run: runCalibrate},
+	{name: "watch", usage: "Report or exit when lux crosses a threshold", run: runWatch},
+	{name: "interrupts", usage: "Program thresholds and print each interrupt", run: runInterrupts},
+	{name: "dump", usage: "Print all registers with their decoded values", run: runDump},
+	{name: "reg", usage: "Read or write a single register", run: runReg},
+	{name: "daemon", usage: "Sample continuously with a control API on a Unix socket", run: runDaemon},
+	{name: "tui", usage: "Show a live dashboard in the terminal", run: runTUI},
+	{name: "record", usage: "Record readings into a SQLite database or export them", run: runRecord},
+}
 
-	for {
-		lux, err := tsl.Lux()
-		if err != nil {
-			log.Panic(err)
+func main() {
+	args := os.Args[1:]
+	cmd := commands[0]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name := args[0]
+		args = args[1:]
+		if name == "help" {
+			printUsage()
+			return
 		}
-		log.Printf("Total Light: %f lux\n", lux)
-
-		ir, err := tsl.Infrared()
-		if err != nil {
-			log.Panic(err)
+		found := false
+		for _, c := range commands {
+			if c.name == name {
+				cmd, found = c, true
+				break
+			}
 		}
-		log.Printf("Infrared light: %d\n", ir)
-
-		visible, err := tsl.Visible()
-		if err != nil {
-			log.Panic(err)
+		if !found {
+			fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+			printUsage()
+			os.Exit(2)
 		}
-		log.Printf("Visible light: %d\n", visible)
+	}
 
-		full, err := tsl.FullSpectrum()
-		if err != nil {
-			log.Panic(err)
-		}
-		log.Printf("Full spectrum (IR + visible) light: %d\n", full)
+	// Stop gracefully on SIGINT and SIGTERM, so the sensor is closed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := cmd.run(ctx, args)
+	stop()
+	if logFile != nil {
+		_ = logFile.Close()
+		log.SetOutput(os.Stderr)
+	}
+	var exitErr exitCodeError
+	switch {
+	case errors.As(err, &exitErr):
+		os.Exit(exitErr.code)
+	case err != nil && !errors.Is(err, context.Canceled):
+		log.Fatal(err)
+	}
+}
 
-		chan0, chan1, err := tsl.RawLuminosity()
-		if err != nil {
-			log.Panic(err)
-		}
-		log.Printf("Raw luminosity: %b (chan0), %b (chan1)\n\n", chan0, chan1)
+// exitCodeError makes the command exit with a specific code without printing an error
+type exitCodeError struct {
+	code int
+}
+
+// Error implements error
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("exit code %d", e.code)
+}
 
-		<-ticker.C
+// printUsage prints the list of commands
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: tsl2591 [command] [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", c.name, c.usage)
 	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `Run "tsl2591 <command> -h" for the flags of a command.`)
+}
+
+// newFlagSet returns a flag set for the command
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("tsl2591 "+name, flag.ExitOnError)
 }
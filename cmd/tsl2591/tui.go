@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// ANSI escape sequences used by the dashboard
+const (
+	ansiClear      = "\x1b[2J"
+	ansiHome       = "\x1b[H"
+	ansiClearLine  = "\x1b[K"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+)
+
+// sparkChars are the levels of the sparkline from low to high
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// runTUI shows a live dashboard in the terminal
+func runTUI(ctx context.Context, args []string) error {
+	fs := newFlagSet("tui")
+	sensor := newSensorFlags(fs)
+	interval := fs.Duration("interval", DefaultInterval, "Time between two readings, at least the integration time")
+	width := fs.Int("width", 60, "Width of the bars and the history")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *interval < sensor.opts.Timing.Duration() {
+		return fmt.Errorf("interval %s is shorter than the integration time %s", *interval, sensor.opts.Timing.Duration())
+	}
+	if *width < 10 {
+		return errors.New("width must be at least 10")
+	}
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	fmt.Print(ansiHideCursor + ansiClear)
+	defer fmt.Print(ansiShowCursor)
+
+	history := make([]float64, 0, *width)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		m, err := tsl.MeasureContext(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			m = tsl2591.Measurement{Err: err}
+		default:
+			if len(history) == *width {
+				history = history[1:]
+			}
+			history = append(history, m.Lux)
+		}
+		drawDashboard(m, history, *width)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// drawDashboard redraws the dashboard in place
+func drawDashboard(m tsl2591.Measurement, history []float64, width int) {
+	var b strings.Builder
+	b.WriteString(ansiHome)
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString(ansiClearLine + "\n")
+	}
+
+	line("TSL2591 - %s (Ctrl-C to quit)", time.Now().Format(time.TimeOnly))
+	line("")
+	if m.Err != nil {
+		line("Error: %v", m.Err)
+	} else {
+		maxCounts := float64(tsl2591.MaxCount)
+		if m.Timing == tsl2591.IntegrationTime100MS {
+			maxCounts = float64(tsl2591.MaxCount100ms)
+		}
+		saturated := ""
+		if m.Saturated {
+			saturated = " (saturated)"
+		}
+		line("Lux:       %.2f%s", m.Lux, saturated)
+		line("Settings:  gain %s, integration time %s", m.Gain, m.Timing)
+		line("")
+		line("Chan0 (full spectrum) %5d %s", m.Chan0, bar(float64(m.Chan0)/maxCounts, width))
+		line("Chan1 (infrared)      %5d %s", m.Chan1, bar(float64(m.Chan1)/maxCounts, width))
+	}
+	line("")
+	line("History (%d readings)", len(history))
+	line("%s", sparkline(history))
+	fmt.Fprint(os.Stdout, b.String())
+}
+
+// bar renders a horizontal bar for a fraction between 0 and 1
+func bar(fraction float64, width int) string {
+	filled := int(math.Round(math.Max(0, math.Min(1, fraction)) * float64(width)))
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(" ", width-filled) + "]"
+}
+
+// sparkline renders the values relative to their minimum and maximum
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if high > low {
+			level = int((v - low) / (high - low) * float64(len(sparkChars)-1))
+		}
+		b.WriteRune(sparkChars[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// Exit codes of the watch command with -exit
+const (
+	exitCodeAbove = 10
+	exitCodeBelow = 11
+)
+
+// crossingEvent is printed as JSON line when a threshold is crossed
+type crossingEvent struct {
+	Event     string    `json:"event"`
+	Threshold float64   `json:"threshold"`
+	Lux       float64   `json:"lux"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// runWatch monitors lux and reports threshold crossings
+func runWatch(ctx context.Context, args []string) error {
+	fs := newFlagSet("watch")
+	sensor := newSensorFlags(fs)
+	var above, below *float64
+	fs.Func("above", "Report when lux rises above this threshold", func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		above = &v
+		return err
+	})
+	fs.Func("below", "Report when lux falls below this threshold", func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		below = &v
+		return err
+	})
+	hysteresis := fs.Float64("hysteresis", 0, "Lux to return past a threshold before it is reported again")
+	hold := fs.Duration("hold", 0, "Time lux must stay past a threshold before it is reported")
+	interval := fs.Duration("interval", DefaultInterval, "Time between two readings, at least the integration time")
+	onAbove := fs.String("on-above", "", "Shell command to run when lux rises above the threshold, with $TSL2591_LUX set")
+	onBelow := fs.String("on-below", "", "Shell command to run when lux falls below the threshold, with $TSL2591_LUX set")
+	errorHandling := newErrorFlags(fs)
+	exit := fs.Bool("exit", false, fmt.Sprintf("Exit on the first crossing with code %d (above) or %d (below) instead of printing events", exitCodeAbove, exitCodeBelow))
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := errorHandling.validate(); err != nil {
+		return err
+	}
+	if above == nil && below == nil {
+		return errors.New("at least one of -above and -below is required")
+	}
+	if *interval < sensor.opts.Timing.Duration() {
+		return fmt.Errorf("interval %s is shorter than the integration time %s", *interval, sensor.opts.Timing.Duration())
+	}
+	tsl, err := sensor.open()
+	if err != nil {
+		return err
+	}
+	defer closeSensor(tsl)
+
+	// Lux which is already past a threshold at startup isn't a crossing,
+	// so those events are suppressed until lux returned within the threshold
+	var seeded, pastAbove, pastBelow bool
+	var crossings []crossingEvent
+	events := tsl2591.NewEvents()
+	if above != nil {
+		events.OnAbove(*above, *hysteresis, *hold, func(m tsl2591.Measurement) {
+			if !pastAbove {
+				crossings = append(crossings, crossingEvent{Event: "above", Threshold: *above, Lux: m.Lux, Timestamp: m.Timestamp})
+			}
+		})
+	}
+	if below != nil {
+		events.OnBelow(*below, *hysteresis, *hold, func(m tsl2591.Measurement) {
+			if !pastBelow {
+				crossings = append(crossings, crossingEvent{Event: "below", Threshold: *below, Lux: m.Lux, Timestamp: m.Timestamp})
+			}
+		})
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		m, ok, err := errorHandling.measure(ctx, tsl)
+		if err != nil {
+			return err
+		}
+		crossings = crossings[:0]
+		if ok {
+			if !seeded {
+				pastAbove = above != nil && m.Lux > *above
+				pastBelow = below != nil && m.Lux < *below
+				seeded = true
+			} else {
+				pastAbove = pastAbove && m.Lux > *above
+				pastBelow = pastBelow && m.Lux < *below
+			}
+			events.Feed(m)
+		}
+		for _, crossing := range crossings {
+			command := *onAbove
+			if crossing.Event == "below" {
+				command = *onBelow
+			}
+			if command != "" {
+				runHook(ctx, command, crossing)
+			}
+			if *exit {
+				code := exitCodeAbove
+				if crossing.Event == "below" {
+					code = exitCodeBelow
+				}
+				return exitCodeError{code: code}
+			}
+			if err := encoder.Encode(crossing); err != nil {
+				return fmt.Errorf("failed to print event: %w", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// runHook runs the shell command for the crossing and logs any failure.
+// The crossing is passed in the environment as TSL2591_EVENT, TSL2591_THRESHOLD, TSL2591_LUX and TSL2591_TIMESTAMP.
+func runHook(ctx context.Context, command string, crossing crossingEvent) {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"TSL2591_EVENT="+crossing.Event,
+		"TSL2591_THRESHOLD="+strconv.FormatFloat(crossing.Threshold, 'f', -1, 64),
+		"TSL2591_LUX="+strconv.FormatFloat(crossing.Lux, 'f', 2, 64),
+		"TSL2591_TIMESTAMP="+crossing.Timestamp.Format(time.RFC3339),
+	)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil && ctx.Err() == nil {
+		log.Printf("Command for %s event failed: %v", crossing.Event, err)
+	}
+}
@@ -0,0 +1,18 @@
+package tsl2591
+
+import "time"
+
+// Conn is the minimal I2C connection required by the driver.
+// It is implemented by periph's *i2c.Dev and by the tinygo subpackage.
+type Conn interface {
+	// Tx writes w and then reads into r in a single transaction. Either can be empty.
+	Tx(w, r []byte) error
+}
+
+// Pin is the minimal GPIO input required to wait for interrupts, see Opts.InterruptPin.
+// It is implemented by periph's gpio.PinIn.
+type Pin interface {
+	// WaitForEdge waits for an edge or until the timeout expired.
+	// Returns false if the timeout expired.
+	WaitForEdge(timeout time.Duration) bool
+}
@@ -1,5 +1,10 @@
 package tsl2591
 
+import (
+	"fmt"
+	"time"
+)
+
 // General purpose constants
 const (
 	// FullSpectrum is channel 0
@@ -11,7 +16,7 @@ const (
 	// Visible is FullSpectrum minus Infrared, i.e. channel 0 - channel 1
 	Visible byte = 2
 
-	// Addr is the default I2C address for the TSL2591
+	// Addr is the default I2C address for the TSL2591. See Opts.Address to override.
 	Addr uint16 = 0x29
 
 	// Device ID of the TSL2591 chip
@@ -23,6 +28,12 @@ const (
 	// ClearInt command for 'Clear ALS and no persist ALS interrupt'
 	ClearInt byte = 0xe7
 
+	// ClearALSInt command for 'Clear ALS interrupt'
+	ClearALSInt byte = 0xe6
+
+	// ClearNoPersistInt command for 'Clear no persist ALS interrupt'
+	ClearNoPersistInt byte = 0xea
+
 	// TestInt command for 'Interrupt set - forces an interrupt'
 	TestInt byte = 0xe4
 
@@ -44,9 +55,24 @@ const (
 	// EnableAIEN permits ALS interrupts to be generated, subject to the persist filter
 	EnableAIEN byte = 0x10
 
+	// EnableSAI puts the device to sleep at the end of the ALS cycle if an interrupt has been generated
+	EnableSAI byte = 0x40
+
 	// EnableNPIEN commands that NP Threshold conditions will generate an interrupt, bypassing the persist filter
 	EnableNPIEN byte = 0x80
 
+	// ControlSRESET triggers a system reset when written to the 'control' register
+	ControlSRESET byte = 0x80
+
+	// StatusAVALID indicates that the ALS channels have completed an integration cycle
+	StatusAVALID byte = 0x01
+
+	// StatusAINT indicates that the device is asserting an ALS interrupt
+	StatusAINT byte = 0x10
+
+	// StatusNPINTR indicates that the device is asserting a no-persist interrupt
+	StatusNPINTR byte = 0x20
+
 	// LuxDF is the Lux cooefficient
 	LuxDF float64 = 408.0
 
@@ -146,6 +172,28 @@ const (
 	IntegrationTime600MS IntegrationTime = 0x05
 )
 
+// valid returns whether the integration time is supported by the sensor
+func (t IntegrationTime) valid() bool {
+	return t <= IntegrationTime600MS
+}
+
+// String implements fmt.Stringer, e.g. "200ms"
+func (t IntegrationTime) String() string {
+	if !t.valid() {
+		return fmt.Sprintf("IntegrationTime(0x%02x)", byte(t))
+	}
+	return fmt.Sprintf("%dms", t.Duration().Milliseconds())
+}
+
+// Duration returns the integration time as a duration.
+// Returns zero for unsupported values.
+func (t IntegrationTime) Duration() time.Duration {
+	if !t.valid() {
+		return 0
+	}
+	return time.Duration(t+1) * 100 * time.Millisecond
+}
+
 type Persist byte
 
 // Constants for adjusting the persistence filter
@@ -199,6 +247,43 @@ const (
 	Persist60 Persist = 0x0f
 )
 
+// persistCycles maps the persist filters with a fixed number of cycles to that number
+var persistCycles = map[Persist]int{
+	Persist2:  2,
+	Persist3:  3,
+	Persist5:  5,
+	Persist10: 10,
+	Persist15: 15,
+	Persist20: 20,
+	Persist25: 25,
+	Persist30: 30,
+	Persist35: 35,
+	Persist40: 40,
+	Persist45: 45,
+	Persist50: 50,
+	Persist55: 55,
+	Persist60: 60,
+}
+
+// valid returns whether the persist filter is supported by the sensor
+func (p Persist) valid() bool {
+	return p <= Persist60
+}
+
+// String implements fmt.Stringer, e.g. "Persist5"
+func (p Persist) String() string {
+	switch p {
+	case PersistEvery:
+		return "PersistEvery"
+	case PersistAny:
+		return "PersistAny"
+	}
+	if cycles, ok := persistCycles[p]; ok {
+		return fmt.Sprintf("Persist%d", cycles)
+	}
+	return fmt.Sprintf("Persist(0x%02x)", byte(p))
+}
+
 type Gain byte
 
 // Constants for adjusting the sensor gain
@@ -215,3 +300,42 @@ const (
 	// GainMax is max gain (9876x)
 	GainMax Gain = 0x30
 )
+
+// valid returns whether the gain is supported by the sensor
+func (g Gain) valid() bool {
+	return g&^GainMax == 0
+}
+
+// String implements fmt.Stringer, e.g. "GainMed (25x)"
+func (g Gain) String() string {
+	var name string
+	switch g {
+	case GainLow:
+		name = "GainLow"
+	case GainMed:
+		name = "GainMed"
+	case GainHigh:
+		name = "GainHigh"
+	case GainMax:
+		name = "GainMax"
+	default:
+		return fmt.Sprintf("Gain(0x%02x)", byte(g))
+	}
+	return fmt.Sprintf("%s (%gx)", name, g.Multiplier())
+}
+
+// Multiplier returns the gain multiplier, e.g. 25 for GainMed.
+// Returns zero for unsupported values.
+func (g Gain) Multiplier() float64 {
+	switch g {
+	case GainLow:
+		return 1
+	case GainMed:
+		return 25
+	case GainHigh:
+		return 428
+	case GainMax:
+		return 9876
+	}
+	return 0
+}
@@ -0,0 +1,64 @@
+package tsl2591
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SenseContinuous takes a measurement every interval in a managed goroutine and
+// emits it on the returned channel. Failed measurements are emitted with Err set.
+// A non-positive interval measures every integration time.
+// Call the returned function or Halt to stop sensing, after which the channel is closed.
+func (tsl *TSL2591) SenseContinuous(interval time.Duration) (<-chan Measurement, func()) {
+	tsl.mu.Lock()
+	if interval <= 0 {
+		interval = tsl.timing.Duration()
+	}
+	if tsl.haltCtx == nil {
+		tsl.haltCtx, tsl.haltCancel = context.WithCancel(context.Background())
+	}
+	ctx, cancel := context.WithCancel(tsl.haltCtx)
+	tsl.sensing.Add(1)
+	tsl.mu.Unlock()
+
+	measurements := make(chan Measurement)
+	done := make(chan struct{})
+	go func() {
+		defer tsl.sensing.Done()
+		defer close(done)
+		defer close(measurements)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			m, err := tsl.MeasureContext(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				m = Measurement{Err: err}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case measurements <- m:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+	return measurements, stop
+}
@@ -0,0 +1,99 @@
+package tsl2591
+
+import "math"
+
+// LightSource selects the conversion factor from lux to PPFD
+type LightSource byte
+
+const (
+	// LightSourceSunlight is direct or diffuse sunlight
+	LightSourceSunlight LightSource = iota
+
+	// LightSourceHPS is a high pressure sodium lamp
+	LightSourceHPS
+
+	// LightSourceMetalHalide is a metal halide lamp
+	LightSourceMetalHalide
+
+	// LightSourceFluorescent is a cool white fluorescent lamp
+	LightSourceFluorescent
+
+	// LightSourceLED is a white LED grow light
+	LightSourceLED
+)
+
+// PPFDFactor returns the conversion factor from lux to PPFD in µmol/m²/s per lux.
+// Returns zero for unknown light sources.
+func (s LightSource) PPFDFactor() float64 {
+	switch s {
+	case LightSourceSunlight:
+		return 0.0185
+	case LightSourceHPS:
+		return 0.0122
+	case LightSourceMetalHalide:
+		return 0.0141
+	case LightSourceFluorescent:
+		return 0.0135
+	case LightSourceLED:
+		return 0.0150
+	}
+	return 0
+}
+
+// LuxToPPFD estimates the photosynthetic photon flux density (PPFD)
+// in µmol/m²/s from a lux value for the provided light source
+func LuxToPPFD(lux float64, source LightSource) float64 {
+	return lux * source.PPFDFactor()
+}
+
+// PPFD measures lux and estimates the photosynthetic photon flux density (PPFD)
+// in µmol/m²/s for the provided light source. This is an approximation,
+// as the sensor response differs from the photosynthetically active spectrum.
+func (tsl *TSL2591) PPFD(source LightSource) (float64, error) {
+	lux, err := tsl.Lux()
+	if err != nil {
+		return 0, err
+	}
+	return LuxToPPFD(lux, source), nil
+}
+
+// IncidentMeterCalibration is the calibration constant C of an incident light meter
+// with a flat receptor, used to derive the exposure value from lux
+const IncidentMeterCalibration float64 = 250
+
+// LuxToEV returns the exposure value (EV) for the provided lux and ISO speed,
+// following EV = log2(lux * iso / C). Returns -Inf for zero lux.
+func LuxToEV(lux, iso float64) float64 {
+	return math.Log2(lux * iso / IncidentMeterCalibration)
+}
+
+// EV measures lux and returns the exposure value (EV) for the provided ISO speed,
+// so the sensor can be used as an incident light meter
+func (tsl *TSL2591) EV(iso float64) (float64, error) {
+	lux, err := tsl.Lux()
+	if err != nil {
+		return 0, err
+	}
+	return LuxToEV(lux, iso), nil
+}
+
+// SkyBrightnessZeroPoint is the illuminance in lux corresponding to
+// a sky brightness of 0 mag/arcsec²
+const SkyBrightnessZeroPoint float64 = 108000
+
+// LuxToSkyBrightness converts lux to a sky brightness in magnitudes per square arcsecond.
+// The offset in magnitudes allows to calibrate the zero point against a reference
+// sky quality meter. Returns +Inf for zero lux.
+func LuxToSkyBrightness(lux, offset float64) float64 {
+	return -2.5*math.Log10(lux/SkyBrightnessZeroPoint) + offset
+}
+
+// SkyBrightness measures lux and converts it to a sky brightness in magnitudes
+// per square arcsecond, using Opts.SkyBrightnessOffset as zero point calibration
+func (tsl *TSL2591) SkyBrightness() (float64, error) {
+	lux, err := tsl.Lux()
+	if err != nil {
+		return 0, err
+	}
+	return LuxToSkyBrightness(lux, tsl.opts.SkyBrightnessOffset), nil
+}
@@ -0,0 +1,97 @@
+package tsl2591
+
+import (
+	"sync"
+	"time"
+)
+
+// DataLogger records measurements into a fixed-size ring buffer,
+// overwriting the oldest measurement when full. It is safe for concurrent use.
+type DataLogger struct {
+	mu     sync.Mutex
+	buffer []Measurement
+	next   int
+	full   bool
+}
+
+// NewDataLogger returns a data logger which holds up to size measurements.
+// A size below 1 is treated as 1.
+func NewDataLogger(size int) *DataLogger {
+	if size < 1 {
+		size = 1
+	}
+	return &DataLogger{buffer: make([]Measurement, size)}
+}
+
+// Record adds a measurement to the logger
+func (l *DataLogger) Record(m Measurement) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buffer[l.next] = m
+	l.next = (l.next + 1) % len(l.buffer)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Len returns the number of recorded measurements
+func (l *DataLogger) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.len()
+}
+
+// All returns all recorded measurements, oldest first
+func (l *DataLogger) All() []Measurement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.last(l.len())
+}
+
+// Last returns the last n recorded measurements, oldest first
+func (l *DataLogger) Last(n int) []Measurement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n > l.len() {
+		n = l.len()
+	}
+	if n < 0 {
+		n = 0
+	}
+	return l.last(n)
+}
+
+// Range returns the recorded measurements with a timestamp
+// in the range [from, to), oldest first
+func (l *DataLogger) Range(from, to time.Time) []Measurement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var result []Measurement
+	for _, m := range l.last(l.len()) {
+		if !m.Timestamp.Before(from) && m.Timestamp.Before(to) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// len returns the number of recorded measurements
+func (l *DataLogger) len() int {
+	if l.full {
+		return len(l.buffer)
+	}
+	return l.next
+}
+
+// last returns a copy of the last n measurements, oldest first
+func (l *DataLogger) last(n int) []Measurement {
+	result := make([]Measurement, n)
+	start := l.next - n
+	if start < 0 {
+		start += len(l.buffer)
+	}
+	for i := range result {
+		result[i] = l.buffer[(start+i)%len(l.buffer)]
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package tsl2591
+
+import (
+	"testing"
+	"time"
+)
+
+// luxValues returns the lux values of the measurements
+func luxValues(measurements []Measurement) []float64 {
+	values := make([]float64, len(measurements))
+	for i, m := range measurements {
+		values[i] = m.Lux
+	}
+	return values
+}
+
+// equalValues returns whether both slices hold the same values
+func equalValues(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDataLogger(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		records  int
+		last     int
+		all      []float64
+		expected []float64
+	}{
+		{name: "empty", size: 3, records: 0, last: 2, all: []float64{}, expected: []float64{}},
+		{name: "partially filled", size: 3, records: 2, last: 5, all: []float64{1, 2}, expected: []float64{1, 2}},
+		{name: "full", size: 3, records: 3, last: 2, all: []float64{1, 2, 3}, expected: []float64{2, 3}},
+		{name: "wrapped", size: 3, records: 5, last: 2, all: []float64{3, 4, 5}, expected: []float64{4, 5}},
+		{name: "negative last", size: 3, records: 2, last: -1, all: []float64{1, 2}, expected: []float64{}},
+		{name: "size below 1", size: 0, records: 2, last: 1, all: []float64{2}, expected: []float64{2}},
+	}
+	for _, tt := range tests {
+		logger := NewDataLogger(tt.size)
+		for i := 1; i <= tt.records; i++ {
+			logger.Record(Measurement{Lux: float64(i)})
+		}
+		if logger.Len() != len(tt.all) {
+			t.Errorf("%s: expected length %d, got %d", tt.name, len(tt.all), logger.Len())
+		}
+		if all := luxValues(logger.All()); !equalValues(all, tt.all) {
+			t.Errorf("%s: expected all %v, got %v", tt.name, tt.all, all)
+		}
+		if last := luxValues(logger.Last(tt.last)); !equalValues(last, tt.expected) {
+			t.Errorf("%s: expected last %d %v, got %v", tt.name, tt.last, tt.expected, last)
+		}
+	}
+}
+
+func TestDataLoggerRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	logger := NewDataLogger(10)
+	for i := 0; i < 5; i++ {
+		logger.Record(Measurement{Lux: float64(i), Timestamp: start.Add(time.Duration(i) * time.Minute)})
+	}
+
+	tests := []struct {
+		from, to time.Time
+		expected []float64
+	}{
+		{from: start, to: start.Add(2 * time.Minute), expected: []float64{0, 1}},
+		{from: start.Add(30 * time.Second), to: start.Add(time.Hour), expected: []float64{1, 2, 3, 4}},
+		{from: start.Add(time.Hour), to: start.Add(2 * time.Hour), expected: []float64{}},
+	}
+	for _, tt := range tests {
+		if values := luxValues(logger.Range(tt.from, tt.to)); !equalValues(values, tt.expected) {
+			t.Errorf("Range(%s, %s): expected %v, got %v", tt.from, tt.to, tt.expected, values)
+		}
+	}
+}
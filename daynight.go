@@ -0,0 +1,107 @@
+package tsl2591
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DayNightTransition is a transition detected by a DayNightDetector
+type DayNightTransition byte
+
+const (
+	// DayStarted is emitted when lux stayed above the day threshold for the debounce period
+	DayStarted DayNightTransition = iota + 1
+
+	// NightStarted is emitted when lux stayed below the night threshold for the debounce period
+	NightStarted
+)
+
+// String implements fmt.Stringer
+func (t DayNightTransition) String() string {
+	switch t {
+	case DayStarted:
+		return "DayStarted"
+	case NightStarted:
+		return "NightStarted"
+	}
+	return fmt.Sprintf("DayNightTransition(%d)", byte(t))
+}
+
+// DayNightDetector detects transitions between day and night.
+// The gap between the night and day thresholds provides hysteresis,
+// while the debounce period ignores short events like passing clouds or car lights.
+// It is safe for concurrent use.
+type DayNightDetector struct {
+	mu       sync.Mutex
+	nightLux float64
+	dayLux   float64
+	debounce time.Duration
+
+	// state is the current state, or zero if not yet known
+	state DayNightTransition
+
+	// pending is the state lux currently points to, or zero if none
+	pending DayNightTransition
+	since   time.Time
+}
+
+// NewDayNightDetector returns a detector which considers it night below nightLux
+// and day above dayLux, once lux stayed there for the debounce period.
+func NewDayNightDetector(nightLux, dayLux float64, debounce time.Duration) *DayNightDetector {
+	return &DayNightDetector{nightLux: nightLux, dayLux: dayLux, debounce: debounce}
+}
+
+// Feed evaluates the measurement and returns a transition if one occurred.
+// The first transition establishes the initial state.
+// Measurements with Err set are ignored.
+func (d *DayNightDetector) Feed(m Measurement) (DayNightTransition, bool) {
+	if m.Err != nil {
+		return 0, false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var candidate DayNightTransition
+	switch {
+	case d.state != DayStarted && m.Lux >= d.dayLux:
+		candidate = DayStarted
+	case d.state != NightStarted && m.Lux <= d.nightLux:
+		candidate = NightStarted
+	}
+	if candidate != d.pending {
+		d.pending = candidate
+		d.since = m.Timestamp
+	}
+	if candidate == 0 || m.Timestamp.Sub(d.since) < d.debounce {
+		return 0, false
+	}
+
+	d.state = candidate
+	d.pending = 0
+	return candidate, true
+}
+
+// IsDay returns whether it is currently day.
+// The second return value is false if the state is not yet known.
+func (d *DayNightDetector) IsDay() (bool, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.state == DayStarted, d.state != 0
+}
+
+// Run feeds all measurements of the channel and emits the detected transitions
+// on the returned channel, which is closed once the input channel is closed
+func (d *DayNightDetector) Run(measurements <-chan Measurement) <-chan DayNightTransition {
+	transitions := make(chan DayNightTransition)
+	go func() {
+		defer close(transitions)
+		for m := range measurements {
+			if transition, ok := d.Feed(m); ok {
+				transitions <- transition
+			}
+		}
+	}()
+	return transitions
+}
@@ -0,0 +1,62 @@
+package tsl2591
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDayNightDetector(t *testing.T) {
+	type transition struct {
+		at         int
+		transition DayNightTransition
+	}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		debounce time.Duration
+		lux      []float64
+		expected []transition
+	}{
+		{name: "initial state", debounce: 2 * time.Second, lux: []float64{150, 150, 150}, expected: []transition{{2, DayStarted}}},
+		{name: "hysteresis", debounce: 2 * time.Second, lux: []float64{150, 150, 150, 50, 50, 50, 5, 5, 5}, expected: []transition{{2, DayStarted}, {8, NightStarted}}},
+		{name: "debounce", debounce: 2 * time.Second, lux: []float64{150, 150, 150, 5, 150, 5, 5, 5}, expected: []transition{{2, DayStarted}, {7, NightStarted}}},
+		{name: "without debounce", lux: []float64{5, 5, 50, 150, 150}, expected: []transition{{0, NightStarted}, {3, DayStarted}}},
+	}
+	for _, tt := range tests {
+		detector := NewDayNightDetector(10, 100, tt.debounce)
+		var transitions []transition
+		for i, lux := range tt.lux {
+			if tr, ok := detector.Feed(Measurement{Lux: lux, Timestamp: start.Add(time.Duration(i) * time.Second)}); ok {
+				transitions = append(transitions, transition{at: i, transition: tr})
+			}
+		}
+		if len(transitions) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, transitions)
+			continue
+		}
+		for i := range transitions {
+			if transitions[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, transitions)
+				break
+			}
+		}
+	}
+}
+
+func TestDayNightDetectorIsDay(t *testing.T) {
+	detector := NewDayNightDetector(10, 100, 0)
+	if _, known := detector.IsDay(); known {
+		t.Error("expected state to be unknown before the first transition")
+	}
+
+	detector.Feed(Measurement{Lux: 500, Err: errors.New("read failed")})
+	if _, known := detector.IsDay(); known {
+		t.Error("expected failed measurement to be ignored")
+	}
+
+	detector.Feed(Measurement{Lux: 500})
+	if day, known := detector.IsDay(); !day || !known {
+		t.Errorf("expected day, got day %t and known %t", day, known)
+	}
+}
@@ -7,6 +7,26 @@ import (
 
 var ErrOverflow = errors.New("overflow reading light channels")
 
+var ErrNotEnabled = errors.New("sensor not enabled")
+
+var ErrClosed = errors.New("sensor closed")
+
+var ErrNotReady = errors.New("sensor not ready")
+
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+var ErrStuck = errors.New("sensor stuck")
+
+var ErrDataTimeout = fmt.Errorf("%w: timed out waiting for valid data", ErrNotReady)
+
+var ErrInvalidGain = errors.New("invalid gain")
+
+var ErrInvalidTiming = errors.New("invalid integration time")
+
+var ErrInvalidPersist = errors.New("invalid persist filter")
+
+var ErrInvalidRegister = errors.New("invalid register address")
+
 type UnexpectedDeviceIDError struct {
 	Expected byte
 	Actual   byte
@@ -15,3 +35,24 @@ type UnexpectedDeviceIDError struct {
 func (e UnexpectedDeviceIDError) Error() string {
 	return fmt.Sprintf("received device ID %x does not match expected device ID %x", e.Actual, e.Expected)
 }
+
+// SaturationError is returned when a light channel overflowed.
+// It matches ErrOverflow with errors.Is.
+type SaturationError struct {
+	// Channel is the channel which overflowed, i.e. FullSpectrum (channel 0)
+	// or Infrared (channel 1). Channel 0 is reported if both overflowed.
+	Channel byte
+	Chan0   uint16
+	Chan1   uint16
+	Gain    Gain
+	Timing  IntegrationTime
+}
+
+func (e SaturationError) Error() string {
+	return fmt.Sprintf("%s: channel %d saturated (chan0 %d, chan1 %d, gain %s, timing %s)",
+		ErrOverflow, e.Channel, e.Chan0, e.Chan1, e.Gain, e.Timing)
+}
+
+func (e SaturationError) Is(target error) bool {
+	return target == ErrOverflow
+}
@@ -0,0 +1,162 @@
+package tsl2591
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Events triggers callbacks when the lux value crosses thresholds.
+// Feed it with measurements, e.g. from SenseContinuous or a Broadcaster.
+// It is safe for concurrent use.
+type Events struct {
+	mu       sync.Mutex
+	triggers []*trigger
+	rates    []rateTrigger
+
+	// previous is the last fed measurement, used to calculate the rate of change
+	previous *Measurement
+}
+
+// RateEvent describes a rapid change of the lux value
+type RateEvent struct {
+	// Measurement is the measurement which completed the change
+	Measurement Measurement
+
+	// Rate is the rate of change in lux per second, negative when falling
+	Rate float64
+}
+
+// rateTrigger is a single registered rate of change threshold
+type rateTrigger struct {
+	luxPerSecond float64
+	callback     func(RateEvent)
+}
+
+// trigger is a single registered threshold
+type trigger struct {
+	above      bool
+	threshold  float64
+	hysteresis float64
+	hold       time.Duration
+	callback   func(Measurement)
+
+	// armed is cleared when the trigger fired and set again
+	// once lux returned past the hysteresis band
+	armed bool
+
+	// since is the timestamp at which lux crossed the threshold,
+	// or zero if it is not crossed
+	since time.Time
+}
+
+// NewEvents returns an empty event engine
+func NewEvents() *Events {
+	return &Events{}
+}
+
+// OnAbove registers a callback which is called once lux rises above threshold
+// and stays above it for at least hold. The trigger fires again only after
+// lux fell below threshold minus hysteresis, which avoids flapping.
+func (e *Events) OnAbove(threshold, hysteresis float64, hold time.Duration, callback func(Measurement)) {
+	e.register(&trigger{above: true, threshold: threshold, hysteresis: hysteresis, hold: hold, callback: callback})
+}
+
+// OnBelow registers a callback which is called once lux falls below threshold
+// and stays below it for at least hold. The trigger fires again only after
+// lux rose above threshold plus hysteresis, which avoids flapping.
+func (e *Events) OnBelow(threshold, hysteresis float64, hold time.Duration, callback func(Measurement)) {
+	e.register(&trigger{above: false, threshold: threshold, hysteresis: hysteresis, hold: hold, callback: callback})
+}
+
+// OnRateOfChange registers a callback which is called when lux changes faster than
+// luxPerSecond between two consecutive measurements, either rising or falling.
+// Useful to detect events like lights being switched on or curtains opening.
+func (e *Events) OnRateOfChange(luxPerSecond float64, callback func(RateEvent)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rates = append(e.rates, rateTrigger{luxPerSecond: luxPerSecond, callback: callback})
+}
+
+// register adds an armed trigger
+func (e *Events) register(t *trigger) {
+	t.armed = true
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.triggers = append(e.triggers, t)
+}
+
+// Feed evaluates all triggers against the measurement and calls the callbacks
+// of the triggers which fire. Measurements with Err set are ignored.
+func (e *Events) Feed(m Measurement) {
+	if m.Err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	var fired []func(Measurement)
+	for _, t := range e.triggers {
+		if t.evaluate(m) {
+			fired = append(fired, t.callback)
+		}
+	}
+	var rateFired []func(RateEvent)
+	var event RateEvent
+	if e.previous != nil {
+		if elapsed := m.Timestamp.Sub(e.previous.Timestamp).Seconds(); elapsed > 0 {
+			event = RateEvent{Measurement: m, Rate: (m.Lux - e.previous.Lux) / elapsed}
+			for _, r := range e.rates {
+				if math.Abs(event.Rate) >= r.luxPerSecond {
+					rateFired = append(rateFired, r.callback)
+				}
+			}
+		}
+	}
+	e.previous = &m
+	e.mu.Unlock()
+
+	for _, callback := range fired {
+		callback(m)
+	}
+	for _, callback := range rateFired {
+		callback(event)
+	}
+}
+
+// Run feeds all measurements of the channel until it is closed
+func (e *Events) Run(measurements <-chan Measurement) {
+	for m := range measurements {
+		e.Feed(m)
+	}
+}
+
+// evaluate updates the trigger state and returns whether it fires
+func (t *trigger) evaluate(m Measurement) bool {
+	crossed := m.Lux > t.threshold
+	rearm := m.Lux < t.threshold-t.hysteresis
+	if !t.above {
+		crossed = m.Lux < t.threshold
+		rearm = m.Lux > t.threshold+t.hysteresis
+	}
+
+	if !t.armed {
+		if rearm {
+			t.armed = true
+		}
+		return false
+	}
+
+	if !crossed {
+		t.since = time.Time{}
+		return false
+	}
+	if t.since.IsZero() {
+		t.since = m.Timestamp
+	}
+	if m.Timestamp.Sub(t.since) < t.hold {
+		return false
+	}
+	t.armed = false
+	t.since = time.Time{}
+	return true
+}
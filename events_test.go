@@ -0,0 +1,85 @@
+package tsl2591
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		above bool
+		hold  time.Duration
+		lux   []float64
+		fired []int
+	}{
+		{name: "above", above: true, lux: []float64{50, 150, 160, 95, 150, 85, 150}, fired: []int{1, 6}},
+		{name: "below", above: false, lux: []float64{150, 50, 40, 105, 50, 120, 50}, fired: []int{1, 6}},
+		{name: "above with hold", above: true, hold: 2 * time.Second, lux: []float64{150, 150, 150, 50, 150, 50}, fired: []int{2}},
+		{name: "below with hold", above: false, hold: time.Second, lux: []float64{50, 150, 50, 50, 50}, fired: []int{3}},
+	}
+	for _, tt := range tests {
+		var fired []int
+		events := NewEvents()
+		register := events.OnBelow
+		if tt.above {
+			register = events.OnAbove
+		}
+		register(100, 10, tt.hold, func(m Measurement) {
+			fired = append(fired, int(m.Timestamp.Sub(start)/time.Second))
+		})
+
+		for i, lux := range tt.lux {
+			events.Feed(Measurement{Lux: lux, Timestamp: start.Add(time.Duration(i) * time.Second)})
+		}
+		if len(fired) != len(tt.fired) {
+			t.Errorf("%s: expected to fire at %v, fired at %v", tt.name, tt.fired, fired)
+			continue
+		}
+		for i := range fired {
+			if fired[i] != tt.fired[i] {
+				t.Errorf("%s: expected to fire at %v, fired at %v", tt.name, tt.fired, fired)
+				break
+			}
+		}
+	}
+}
+
+func TestEventsIgnoreErrors(t *testing.T) {
+	events := NewEvents()
+	events.OnAbove(100, 0, 0, func(m Measurement) {
+		t.Errorf("expected failed measurement to be ignored, got %+v", m)
+	})
+	events.Feed(Measurement{Lux: 150, Err: errors.New("read failed")})
+}
+
+func TestEventsRateOfChange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		offsets  []time.Duration
+		lux      []float64
+		expected []float64
+	}{
+		{name: "steady", offsets: []time.Duration{0, time.Second, 2 * time.Second}, lux: []float64{100, 110, 120}, expected: nil},
+		{name: "rising", offsets: []time.Duration{0, time.Second, 2 * time.Second}, lux: []float64{100, 200, 210}, expected: []float64{100}},
+		{name: "falling", offsets: []time.Duration{0, 2 * time.Second}, lux: []float64{500, 100}, expected: []float64{-200}},
+		{name: "same timestamp", offsets: []time.Duration{0, 0}, lux: []float64{100, 500}, expected: nil},
+	}
+	for _, tt := range tests {
+		var rates []float64
+		events := NewEvents()
+		events.OnRateOfChange(50, func(e RateEvent) {
+			rates = append(rates, e.Rate)
+		})
+
+		for i, lux := range tt.lux {
+			events.Feed(Measurement{Lux: lux, Timestamp: start.Add(tt.offsets[i])})
+		}
+		if !equalValues(rates, tt.expected) {
+			t.Errorf("%s: expected rates %v, got %v", tt.name, tt.expected, rates)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+//go:build !tinygo
+
+package tsl2591
+
+import (
+	"expvar"
+	"fmt"
+)
+
+// ExpvarSnapshot is the value published by PublishExpvar
+type ExpvarSnapshot struct {
+	Measurement Measurement
+	Metrics     Metrics
+}
+
+// PublishExpvar publishes the latest measurement and driver metrics via expvar under the given name.
+// The values are taken from LastMeasurement and Metrics, so publishing doesn't trigger a reading.
+// Returns an error if a variable with the same name was already published.
+func (tsl *TSL2591) PublishExpvar(name string) error {
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %q is already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() any {
+		return ExpvarSnapshot{
+			Measurement: tsl.LastMeasurement(),
+			Metrics:     tsl.Metrics(),
+		}
+	}))
+	return nil
+}
@@ -0,0 +1,39 @@
+package tsl2591
+
+// EMA is an exponential moving average filter. It is not safe for concurrent use.
+type EMA struct {
+	// Alpha is the smoothing factor between 0 and 1.
+	// Higher values follow changes faster, lower values smooth more.
+	Alpha float64
+
+	value       float64
+	initialized bool
+}
+
+// NewEMA returns an exponential moving average filter with the provided smoothing factor
+func NewEMA(alpha float64) *EMA {
+	return &EMA{Alpha: alpha}
+}
+
+// Add adds a value to the filter and returns the new average.
+// The first value initializes the average.
+func (e *EMA) Add(value float64) float64 {
+	if !e.initialized {
+		e.value = value
+		e.initialized = true
+		return e.value
+	}
+	e.value += e.Alpha * (value - e.value)
+	return e.value
+}
+
+// Value returns the current average
+func (e *EMA) Value() float64 {
+	return e.value
+}
+
+// Reset clears the filter, so the next value initializes the average again
+func (e *EMA) Reset() {
+	e.value = 0
+	e.initialized = false
+}
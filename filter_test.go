@@ -0,0 +1,41 @@
+package tsl2591
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEMA(t *testing.T) {
+	tests := []struct {
+		name     string
+		alpha    float64
+		values   []float64
+		expected []float64
+	}{
+		{name: "first value initializes", alpha: 0.5, values: []float64{10}, expected: []float64{10}},
+		{name: "half", alpha: 0.5, values: []float64{10, 20, 20}, expected: []float64{10, 15, 17.5}},
+		{name: "quarter", alpha: 0.25, values: []float64{0, 100, 100}, expected: []float64{0, 25, 43.75}},
+		{name: "no smoothing", alpha: 1, values: []float64{1, 5, 3}, expected: []float64{1, 5, 3}},
+	}
+	for _, tt := range tests {
+		ema := NewEMA(tt.alpha)
+		for i, value := range tt.values {
+			if result := ema.Add(value); math.Abs(result-tt.expected[i]) > 1e-9 {
+				t.Errorf("%s: value %d: expected %f, got %f", tt.name, i, tt.expected[i], result)
+			}
+		}
+		if math.Abs(ema.Value()-tt.expected[len(tt.expected)-1]) > 1e-9 {
+			t.Errorf("%s: expected value %f, got %f", tt.name, tt.expected[len(tt.expected)-1], ema.Value())
+		}
+	}
+}
+
+func TestEMAReset(t *testing.T) {
+	ema := NewEMA(0.5)
+	ema.Add(10)
+	ema.Add(20)
+	ema.Reset()
+	if result := ema.Add(100); result != 100 {
+		t.Errorf("expected first value after reset to initialize the average, got %f", result)
+	}
+}
@@ -0,0 +1,163 @@
+// Package grpc exposes a TSL2591 sensor as gRPC service.
+// The client and server stubs are generated from tsl2591.proto.
+//
+// Example:
+//
+//	lis, err := net.Listen("tcp", ":50051")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	server := grpc.NewServer()
+//	tslgrpc.RegisterSensorServer(server, tslgrpc.NewServer(sensor))
+//	log.Fatal(server.Serve(lis))
+package grpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative tsl2591.proto
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements SensorServer for a TSL2591
+type Server struct {
+	UnimplementedSensorServer
+	sensor *tsl2591.TSL2591
+}
+
+// NewServer creates a new server for the sensor
+func NewServer(sensor *tsl2591.TSL2591) *Server {
+	return &Server{sensor: sensor}
+}
+
+// GetMeasurement takes a single measurement
+func (s *Server) GetMeasurement(ctx context.Context, _ *GetMeasurementRequest) (*Measurement, error) {
+	m, err := s.sensor.MeasureContext(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return fromMeasurement(m), nil
+}
+
+// StreamMeasurements takes a measurement every interval until the client cancels.
+// Failed measurements are skipped.
+func (s *Server) StreamMeasurements(req *StreamMeasurementsRequest, stream Sensor_StreamMeasurementsServer) error {
+	interval := req.GetInterval().AsDuration()
+	if interval < 0 {
+		return status.Error(codes.InvalidArgument, "interval must not be negative")
+	}
+	if interval == 0 {
+		interval = s.sensor.GetTiming().Duration()
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m, err := s.sensor.MeasureContext(ctx)
+		if err == nil {
+			if err := stream.Send(fromMeasurement(m)); err != nil {
+				return err
+			}
+		} else if ctx.Err() != nil {
+			return toStatus(ctx.Err())
+		}
+
+		select {
+		case <-ctx.Done():
+			return toStatus(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// SetConfig updates the provided settings and returns the effective configuration
+func (s *Server) SetConfig(_ context.Context, config *Config) (*Config, error) {
+	if config.AutoGain != nil {
+		s.sensor.SetAutoGain(config.GetAutoGain())
+	}
+	if config.GetGain() != Gain_GAIN_UNSPECIFIED {
+		if err := s.sensor.SetGain(toGain(config.GetGain())); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+	if config.GetTiming() != IntegrationTime_INTEGRATION_TIME_UNSPECIFIED {
+		if err := s.sensor.SetTiming(toIntegrationTime(config.GetTiming())); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	autoGain := s.sensor.GetAutoRange() != nil
+	return &Config{
+		Gain:     fromGain(s.sensor.GetGain()),
+		Timing:   fromIntegrationTime(s.sensor.GetTiming()),
+		AutoGain: &autoGain,
+	}, nil
+}
+
+// fromMeasurement converts a measurement to its protobuf message
+func fromMeasurement(m tsl2591.Measurement) *Measurement {
+	return &Measurement{
+		Timestamp:    timestamppb.New(m.Timestamp),
+		Lux:          m.Lux,
+		Visible:      m.Visible,
+		Infrared:     uint32(m.Infrared),
+		FullSpectrum: m.FullSpectrum,
+		Chan0:        uint32(m.Chan0),
+		Chan1:        uint32(m.Chan1),
+		Gain:         fromGain(m.Gain),
+		Timing:       fromIntegrationTime(m.Timing),
+		Saturated:    m.Saturated,
+		Fresh:        m.Fresh,
+	}
+}
+
+// fromGain converts a gain to its protobuf enum
+func fromGain(gain tsl2591.Gain) Gain {
+	return Gain(gain>>4) + Gain_GAIN_LOW
+}
+
+// toGain converts a protobuf gain to the driver gain.
+// Unknown values result in an invalid gain, which is rejected by SetGain.
+func toGain(gain Gain) tsl2591.Gain {
+	if gain < Gain_GAIN_LOW || gain > Gain_GAIN_MAX {
+		return 0xff
+	}
+	return tsl2591.Gain(gain-Gain_GAIN_LOW) << 4
+}
+
+// fromIntegrationTime converts an integration time to its protobuf enum
+func fromIntegrationTime(timing tsl2591.IntegrationTime) IntegrationTime {
+	return IntegrationTime(timing) + IntegrationTime_INTEGRATION_TIME_100MS
+}
+
+// toIntegrationTime converts a protobuf integration time to the driver integration time.
+// Unknown values result in an invalid timing, which is rejected by SetTiming.
+func toIntegrationTime(timing IntegrationTime) tsl2591.IntegrationTime {
+	if timing < IntegrationTime_INTEGRATION_TIME_100MS || timing > IntegrationTime_INTEGRATION_TIME_600MS {
+		return 0xff
+	}
+	return tsl2591.IntegrationTime(timing - IntegrationTime_INTEGRATION_TIME_100MS)
+}
+
+// toStatus converts a driver error to a gRPC status error
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, tsl2591.ErrInvalidGain), errors.Is(err, tsl2591.ErrInvalidTiming):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tsl2591.ErrOverflow):
+		return status.Error(codes.OutOfRange, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
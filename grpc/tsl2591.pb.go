@@ -0,0 +1,623 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: tsl2591.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Gain int32
+
+const (
+	Gain_GAIN_UNSPECIFIED Gain = 0
+	Gain_GAIN_LOW         Gain = 1
+	Gain_GAIN_MED         Gain = 2
+	Gain_GAIN_HIGH        Gain = 3
+	Gain_GAIN_MAX         Gain = 4
+)
+
+// Enum value maps for Gain.
+var (
+	Gain_name = map[int32]string{
+		0: "GAIN_UNSPECIFIED",
+		1: "GAIN_LOW",
+		2: "GAIN_MED",
+		3: "GAIN_HIGH",
+		4: "GAIN_MAX",
+	}
+	Gain_value = map[string]int32{
+		"GAIN_UNSPECIFIED": 0,
+		"GAIN_LOW":         1,
+		"GAIN_MED":         2,
+		"GAIN_HIGH":        3,
+		"GAIN_MAX":         4,
+	}
+)
+
+func (x Gain) Enum() *Gain {
+	p := new(Gain)
+	*p = x
+	return p
+}
+
+func (x Gain) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Gain) Descriptor() protoreflect.EnumDescriptor {
+	return file_tsl2591_proto_enumTypes[0].Descriptor()
+}
+
+func (Gain) Type() protoreflect.EnumType {
+	return &file_tsl2591_proto_enumTypes[0]
+}
+
+func (x Gain) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Gain.Descriptor instead.
+func (Gain) EnumDescriptor() ([]byte, []int) {
+	return file_tsl2591_proto_rawDescGZIP(), []int{0}
+}
+
+type IntegrationTime int32
+
+const (
+	IntegrationTime_INTEGRATION_TIME_UNSPECIFIED IntegrationTime = 0
+	IntegrationTime_INTEGRATION_TIME_100MS       IntegrationTime = 1
+	IntegrationTime_INTEGRATION_TIME_200MS       IntegrationTime = 2
+	IntegrationTime_INTEGRATION_TIME_300MS       IntegrationTime = 3
+	IntegrationTime_INTEGRATION_TIME_400MS       IntegrationTime = 4
+	IntegrationTime_INTEGRATION_TIME_500MS       IntegrationTime = 5
+	IntegrationTime_INTEGRATION_TIME_600MS       IntegrationTime = 6
+)
+
+// Enum value maps for IntegrationTime.
+var (
+	IntegrationTime_name = map[int32]string{
+		0: "INTEGRATION_TIME_UNSPECIFIED",
+		1: "INTEGRATION_TIME_100MS",
+		2: "INTEGRATION_TIME_200MS",
+		3: "INTEGRATION_TIME_300MS",
+		4: "INTEGRATION_TIME_400MS",
+		5: "INTEGRATION_TIME_500MS",
+		6: "INTEGRATION_TIME_600MS",
+	}
+	IntegrationTime_value = map[string]int32{
+		"INTEGRATION_TIME_UNSPECIFIED": 0,
+		"INTEGRATION_TIME_100MS":       1,
+		"INTEGRATION_TIME_200MS":       2,
+		"INTEGRATION_TIME_300MS":       3,
+		"INTEGRATION_TIME_400MS":       4,
+		"INTEGRATION_TIME_500MS":       5,
+		"INTEGRATION_TIME_600MS":       6,
+	}
+)
+
+func (x IntegrationTime) Enum() *IntegrationTime {
+	p := new(IntegrationTime)
+	*p = x
+	return p
+}
+
+func (x IntegrationTime) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IntegrationTime) Descriptor() protoreflect.EnumDescriptor {
+	return file_tsl2591_proto_enumTypes[1].Descriptor()
+}
+
+func (IntegrationTime) Type() protoreflect.EnumType {
+	return &file_tsl2591_proto_enumTypes[1]
+}
+
+func (x IntegrationTime) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IntegrationTime.Descriptor instead.
+func (IntegrationTime) EnumDescriptor() ([]byte, []int) {
+	return file_tsl2591_proto_rawDescGZIP(), []int{1}
+}
+
+type GetMeasurementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetMeasurementRequest) Reset() {
+	*x = GetMeasurementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tsl2591_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMeasurementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeasurementRequest) ProtoMessage() {}
+
+func (x *GetMeasurementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tsl2591_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeasurementRequest.ProtoReflect.Descriptor instead.
+func (*GetMeasurementRequest) Descriptor() ([]byte, []int) {
+	return file_tsl2591_proto_rawDescGZIP(), []int{0}
+}
+
+type StreamMeasurementsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// interval between two measurements, defaults to the integration time
+	Interval *durationpb.Duration `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *StreamMeasurementsRequest) Reset() {
+	*x = StreamMeasurementsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tsl2591_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamMeasurementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMeasurementsRequest) ProtoMessage() {}
+
+func (x *StreamMeasurementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tsl2591_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMeasurementsRequest.ProtoReflect.Descriptor instead.
+func (*StreamMeasurementsRequest) Descriptor() ([]byte, []int) {
+	return file_tsl2591_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamMeasurementsRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type Measurement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Lux          float64                `protobuf:"fixed64,2,opt,name=lux,proto3" json:"lux,omitempty"`
+	Visible      uint32                 `protobuf:"varint,3,opt,name=visible,proto3" json:"visible,omitempty"`
+	Infrared     uint32                 `protobuf:"varint,4,opt,name=infrared,proto3" json:"infrared,omitempty"`
+	FullSpectrum uint32                 `protobuf:"varint,5,opt,name=full_spectrum,json=fullSpectrum,proto3" json:"full_spectrum,omitempty"`
+	Chan0        uint32                 `protobuf:"varint,6,opt,name=chan0,proto3" json:"chan0,omitempty"`
+	Chan1        uint32                 `protobuf:"varint,7,opt,name=chan1,proto3" json:"chan1,omitempty"`
+	Gain         Gain                   `protobuf:"varint,8,opt,name=gain,proto3,enum=tsl2591.Gain" json:"gain,omitempty"`
+	Timing       IntegrationTime        `protobuf:"varint,9,opt,name=timing,proto3,enum=tsl2591.IntegrationTime" json:"timing,omitempty"`
+	// saturated is set when a channel overflowed and lux was clamped to the maximum
+	Saturated bool `protobuf:"varint,10,opt,name=saturated,proto3" json:"saturated,omitempty"`
+	// fresh is set when the channels hold a new conversion
+	Fresh bool `protobuf:"varint,11,opt,name=fresh,proto3" json:"fresh,omitempty"`
+}
+
+func (x *Measurement) Reset() {
+	*x = Measurement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tsl2591_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Measurement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Measurement) ProtoMessage() {}
+
+func (x *Measurement) ProtoReflect() protoreflect.Message {
+	mi := &file_tsl2591_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Measurement.ProtoReflect.Descriptor instead.
+func (*Measurement) Descriptor() ([]byte, []int) {
+	return file_tsl2591_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Measurement) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Measurement) GetLux() float64 {
+	if x != nil {
+		return x.Lux
+	}
+	return 0
+}
+
+func (x *Measurement) GetVisible() uint32 {
+	if x != nil {
+		return x.Visible
+	}
+	return 0
+}
+
+func (x *Measurement) GetInfrared() uint32 {
+	if x != nil {
+		return x.Infrared
+	}
+	return 0
+}
+
+func (x *Measurement) GetFullSpectrum() uint32 {
+	if x != nil {
+		return x.FullSpectrum
+	}
+	return 0
+}
+
+func (x *Measurement) GetChan0() uint32 {
+	if x != nil {
+		return x.Chan0
+	}
+	return 0
+}
+
+func (x *Measurement) GetChan1() uint32 {
+	if x != nil {
+		return x.Chan1
+	}
+	return 0
+}
+
+func (x *Measurement) GetGain() Gain {
+	if x != nil {
+		return x.Gain
+	}
+	return Gain_GAIN_UNSPECIFIED
+}
+
+func (x *Measurement) GetTiming() IntegrationTime {
+	if x != nil {
+		return x.Timing
+	}
+	return IntegrationTime_INTEGRATION_TIME_UNSPECIFIED
+}
+
+func (x *Measurement) GetSaturated() bool {
+	if x != nil {
+		return x.Saturated
+	}
+	return false
+}
+
+func (x *Measurement) GetFresh() bool {
+	if x != nil {
+		return x.Fresh
+	}
+	return false
+}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gain     Gain            `protobuf:"varint,1,opt,name=gain,proto3,enum=tsl2591.Gain" json:"gain,omitempty"`
+	Timing   IntegrationTime `protobuf:"varint,2,opt,name=timing,proto3,enum=tsl2591.IntegrationTime" json:"timing,omitempty"`
+	AutoGain *bool           `protobuf:"varint,3,opt,name=auto_gain,json=autoGain,proto3,oneof" json:"auto_gain,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tsl2591_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_tsl2591_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_tsl2591_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Config) GetGain() Gain {
+	if x != nil {
+		return x.Gain
+	}
+	return Gain_GAIN_UNSPECIFIED
+}
+
+func (x *Config) GetTiming() IntegrationTime {
+	if x != nil {
+		return x.Timing
+	}
+	return IntegrationTime_INTEGRATION_TIME_UNSPECIFIED
+}
+
+func (x *Config) GetAutoGain() bool {
+	if x != nil && x.AutoGain != nil {
+		return *x.AutoGain
+	}
+	return false
+}
+
+var File_tsl2591_proto protoreflect.FileDescriptor
+
+var file_tsl2591_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x07, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x52, 0x0a, 0x19, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d, 0x65, 0x61, 0x73,
+	0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xe9, 0x02, 0x0a, 0x0b, 0x4d, 0x65, 0x61, 0x73, 0x75,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x75, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c,
+	0x75, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x66, 0x72, 0x61, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x69, 0x6e, 0x66, 0x72, 0x61, 0x72, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x75, 0x6c, 0x6c,
+	0x5f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x72, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x66, 0x75, 0x6c, 0x6c, 0x53, 0x70, 0x65, 0x63, 0x74, 0x72, 0x75, 0x6d, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x68, 0x61, 0x6e, 0x30, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x68,
+	0x61, 0x6e, 0x30, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x6e, 0x31, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x63, 0x68, 0x61, 0x6e, 0x31, 0x12, 0x21, 0x0a, 0x04, 0x67, 0x61, 0x69,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39,
+	0x31, 0x2e, 0x47, 0x61, 0x69, 0x6e, 0x52, 0x04, 0x67, 0x61, 0x69, 0x6e, 0x12, 0x30, 0x0a, 0x06,
+	0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x74,
+	0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x06, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x22, 0x8d, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a,
+	0x04, 0x67, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x73,
+	0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x47, 0x61, 0x69, 0x6e, 0x52, 0x04, 0x67, 0x61, 0x69, 0x6e,
+	0x12, 0x30, 0x0a, 0x06, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x18, 0x2e, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x06, 0x74, 0x69, 0x6d, 0x69,
+	0x6e, 0x67, 0x12, 0x20, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x67, 0x61, 0x69, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x47, 0x61, 0x69,
+	0x6e, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x67, 0x61,
+	0x69, 0x6e, 0x2a, 0x55, 0x0a, 0x04, 0x47, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x41,
+	0x49, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0c, 0x0a, 0x08, 0x47, 0x41, 0x49, 0x4e, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x01, 0x12, 0x0c,
+	0x0a, 0x08, 0x47, 0x41, 0x49, 0x4e, 0x5f, 0x4d, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09,
+	0x47, 0x41, 0x49, 0x4e, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x47,
+	0x41, 0x49, 0x4e, 0x5f, 0x4d, 0x41, 0x58, 0x10, 0x04, 0x2a, 0xdb, 0x01, 0x0a, 0x0f, 0x49, 0x6e,
+	0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x1c, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x1a, 0x0a, 0x16, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54,
+	0x49, 0x4d, 0x45, 0x5f, 0x31, 0x30, 0x30, 0x4d, 0x53, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x49,
+	0x4e, 0x54, 0x45, 0x47, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5f,
+	0x32, 0x30, 0x30, 0x4d, 0x53, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x49, 0x4e, 0x54, 0x45, 0x47,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x33, 0x30, 0x30, 0x4d,
+	0x53, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x52, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x34, 0x30, 0x30, 0x4d, 0x53, 0x10, 0x04, 0x12,
+	0x1a, 0x0a, 0x16, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54,
+	0x49, 0x4d, 0x45, 0x5f, 0x35, 0x30, 0x30, 0x4d, 0x53, 0x10, 0x05, 0x12, 0x1a, 0x0a, 0x16, 0x49,
+	0x4e, 0x54, 0x45, 0x47, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5f,
+	0x36, 0x30, 0x30, 0x4d, 0x53, 0x10, 0x06, 0x32, 0xd1, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x12, 0x46, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x4d,
+	0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x50, 0x0a, 0x12, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x22, 0x2e, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31, 0x2e, 0x4d,
+	0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x2d, 0x0a, 0x09,
+	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x0f, 0x2e, 0x74, 0x73, 0x6c, 0x32,
+	0x35, 0x39, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x0f, 0x2e, 0x74, 0x73, 0x6c,
+	0x32, 0x35, 0x39, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x28, 0x5a, 0x26, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4a, 0x65, 0x6e, 0x73, 0x77, 0x42,
+	0x45, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2d, 0x74, 0x73, 0x6c, 0x32, 0x35, 0x39, 0x31,
+	0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tsl2591_proto_rawDescOnce sync.Once
+	file_tsl2591_proto_rawDescData = file_tsl2591_proto_rawDesc
+)
+
+func file_tsl2591_proto_rawDescGZIP() []byte {
+	file_tsl2591_proto_rawDescOnce.Do(func() {
+		file_tsl2591_proto_rawDescData = protoimpl.X.CompressGZIP(file_tsl2591_proto_rawDescData)
+	})
+	return file_tsl2591_proto_rawDescData
+}
+
+var file_tsl2591_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_tsl2591_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_tsl2591_proto_goTypes = []any{
+	(Gain)(0),                         // 0: tsl2591.Gain
+	(IntegrationTime)(0),              // 1: tsl2591.IntegrationTime
+	(*GetMeasurementRequest)(nil),     // 2: tsl2591.GetMeasurementRequest
+	(*StreamMeasurementsRequest)(nil), // 3: tsl2591.StreamMeasurementsRequest
+	(*Measurement)(nil),               // 4: tsl2591.Measurement
+	(*Config)(nil),                    // 5: tsl2591.Config
+	(*durationpb.Duration)(nil),       // 6: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+}
+var file_tsl2591_proto_depIdxs = []int32{
+	6, // 0: tsl2591.StreamMeasurementsRequest.interval:type_name -> google.protobuf.Duration
+	7, // 1: tsl2591.Measurement.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 2: tsl2591.Measurement.gain:type_name -> tsl2591.Gain
+	1, // 3: tsl2591.Measurement.timing:type_name -> tsl2591.IntegrationTime
+	0, // 4: tsl2591.Config.gain:type_name -> tsl2591.Gain
+	1, // 5: tsl2591.Config.timing:type_name -> tsl2591.IntegrationTime
+	2, // 6: tsl2591.Sensor.GetMeasurement:input_type -> tsl2591.GetMeasurementRequest
+	3, // 7: tsl2591.Sensor.StreamMeasurements:input_type -> tsl2591.StreamMeasurementsRequest
+	5, // 8: tsl2591.Sensor.SetConfig:input_type -> tsl2591.Config
+	4, // 9: tsl2591.Sensor.GetMeasurement:output_type -> tsl2591.Measurement
+	4, // 10: tsl2591.Sensor.StreamMeasurements:output_type -> tsl2591.Measurement
+	5, // 11: tsl2591.Sensor.SetConfig:output_type -> tsl2591.Config
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_tsl2591_proto_init() }
+func file_tsl2591_proto_init() {
+	if File_tsl2591_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tsl2591_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMeasurementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tsl2591_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamMeasurementsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tsl2591_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Measurement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tsl2591_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_tsl2591_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tsl2591_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tsl2591_proto_goTypes,
+		DependencyIndexes: file_tsl2591_proto_depIdxs,
+		EnumInfos:         file_tsl2591_proto_enumTypes,
+		MessageInfos:      file_tsl2591_proto_msgTypes,
+	}.Build()
+	File_tsl2591_proto = out.File
+	file_tsl2591_proto_rawDesc = nil
+	file_tsl2591_proto_goTypes = nil
+	file_tsl2591_proto_depIdxs = nil
+}
@@ -0,0 +1,226 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: tsl2591.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Sensor_GetMeasurement_FullMethodName     = "/tsl2591.Sensor/GetMeasurement"
+	Sensor_StreamMeasurements_FullMethodName = "/tsl2591.Sensor/StreamMeasurements"
+	Sensor_SetConfig_FullMethodName          = "/tsl2591.Sensor/SetConfig"
+)
+
+// SensorClient is the client API for Sensor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Sensor exposes a TSL2591 light sensor
+type SensorClient interface {
+	// GetMeasurement takes a single measurement
+	GetMeasurement(ctx context.Context, in *GetMeasurementRequest, opts ...grpc.CallOption) (*Measurement, error)
+	// StreamMeasurements takes a measurement every interval until the client cancels
+	StreamMeasurements(ctx context.Context, in *StreamMeasurementsRequest, opts ...grpc.CallOption) (Sensor_StreamMeasurementsClient, error)
+	// SetConfig updates the provided settings and returns the effective configuration.
+	// Unspecified settings are left unchanged, so an empty Config returns the current configuration.
+	SetConfig(ctx context.Context, in *Config, opts ...grpc.CallOption) (*Config, error)
+}
+
+type sensorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSensorClient(cc grpc.ClientConnInterface) SensorClient {
+	return &sensorClient{cc}
+}
+
+func (c *sensorClient) GetMeasurement(ctx context.Context, in *GetMeasurementRequest, opts ...grpc.CallOption) (*Measurement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Measurement)
+	err := c.cc.Invoke(ctx, Sensor_GetMeasurement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensorClient) StreamMeasurements(ctx context.Context, in *StreamMeasurementsRequest, opts ...grpc.CallOption) (Sensor_StreamMeasurementsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Sensor_ServiceDesc.Streams[0], Sensor_StreamMeasurements_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sensorStreamMeasurementsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sensor_StreamMeasurementsClient interface {
+	Recv() (*Measurement, error)
+	grpc.ClientStream
+}
+
+type sensorStreamMeasurementsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sensorStreamMeasurementsClient) Recv() (*Measurement, error) {
+	m := new(Measurement)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sensorClient) SetConfig(ctx context.Context, in *Config, opts ...grpc.CallOption) (*Config, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Sensor_SetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SensorServer is the server API for Sensor service.
+// All implementations must embed UnimplementedSensorServer
+// for forward compatibility
+//
+// Sensor exposes a TSL2591 light sensor
+type SensorServer interface {
+	// GetMeasurement takes a single measurement
+	GetMeasurement(context.Context, *GetMeasurementRequest) (*Measurement, error)
+	// StreamMeasurements takes a measurement every interval until the client cancels
+	StreamMeasurements(*StreamMeasurementsRequest, Sensor_StreamMeasurementsServer) error
+	// SetConfig updates the provided settings and returns the effective configuration.
+	// Unspecified settings are left unchanged, so an empty Config returns the current configuration.
+	SetConfig(context.Context, *Config) (*Config, error)
+	mustEmbedUnimplementedSensorServer()
+}
+
+// UnimplementedSensorServer must be embedded to have forward compatible implementations.
+type UnimplementedSensorServer struct {
+}
+
+func (UnimplementedSensorServer) GetMeasurement(context.Context, *GetMeasurementRequest) (*Measurement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeasurement not implemented")
+}
+func (UnimplementedSensorServer) StreamMeasurements(*StreamMeasurementsRequest, Sensor_StreamMeasurementsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMeasurements not implemented")
+}
+func (UnimplementedSensorServer) SetConfig(context.Context, *Config) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfig not implemented")
+}
+func (UnimplementedSensorServer) mustEmbedUnimplementedSensorServer() {}
+
+// UnsafeSensorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SensorServer will
+// result in compilation errors.
+type UnsafeSensorServer interface {
+	mustEmbedUnimplementedSensorServer()
+}
+
+func RegisterSensorServer(s grpc.ServiceRegistrar, srv SensorServer) {
+	s.RegisterService(&Sensor_ServiceDesc, srv)
+}
+
+func _Sensor_GetMeasurement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMeasurementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServer).GetMeasurement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sensor_GetMeasurement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServer).GetMeasurement(ctx, req.(*GetMeasurementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sensor_StreamMeasurements_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMeasurementsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SensorServer).StreamMeasurements(m, &sensorStreamMeasurementsServer{ServerStream: stream})
+}
+
+type Sensor_StreamMeasurementsServer interface {
+	Send(*Measurement) error
+	grpc.ServerStream
+}
+
+type sensorStreamMeasurementsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sensorStreamMeasurementsServer) Send(m *Measurement) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Sensor_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensorServer).SetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sensor_SetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensorServer).SetConfig(ctx, req.(*Config))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Sensor_ServiceDesc is the grpc.ServiceDesc for Sensor service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sensor_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tsl2591.Sensor",
+	HandlerType: (*SensorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMeasurement",
+			Handler:    _Sensor_GetMeasurement_Handler,
+		},
+		{
+			MethodName: "SetConfig",
+			Handler:    _Sensor_SetConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMeasurements",
+			Handler:       _Sensor_StreamMeasurements_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tsl2591.proto",
+}
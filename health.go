@@ -0,0 +1,49 @@
+package tsl2591
+
+import "fmt"
+
+// Ping verifies that the sensor responds on the bus with the expected device ID
+func (tsl *TSL2591) Ping() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.ping()
+}
+
+// ping verifies that the sensor responds on the bus with the expected device ID
+func (tsl *TSL2591) ping() error {
+	deviceID, err := tsl.readU8(RegisterDeviceID)
+	if err != nil {
+		return fmt.Errorf("unable to read device ID from I2C bus: %w", err)
+	}
+	if deviceID != DeviceID {
+		return UnexpectedDeviceIDError{Actual: deviceID, Expected: DeviceID}
+	}
+	return nil
+}
+
+// Healthy verifies that the sensor is responsive and producing valid conversions,
+// which makes it suitable for liveness probes. Returns nil if healthy, an error
+// from Ping if the sensor doesn't respond, ErrNotEnabled if the sensor is disabled
+// or ErrNotReady if the ALS channels don't hold a valid conversion.
+func (tsl *TSL2591) Healthy() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	if err := tsl.ping(); err != nil {
+		return err
+	}
+	enabled, err := tsl.isEnabled()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrNotEnabled
+	}
+	status, err := tsl.status()
+	if err != nil {
+		return err
+	}
+	if !status.ALSValid {
+		return fmt.Errorf("%w: no valid conversion", ErrNotReady)
+	}
+	return nil
+}
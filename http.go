@@ -0,0 +1,69 @@
+//go:build !tinygo
+
+package tsl2591
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+// HTTPMeasurement is the JSON body served by the handler returned by HTTPHandler
+type HTTPMeasurement struct {
+	Measurement
+
+	// Age is the time in seconds since the measurement was taken
+	Age float64 `json:"age_seconds"`
+
+	// Stale is set when the measurement is older than the max age,
+	// because taking a new measurement failed
+	Stale bool `json:"stale"`
+}
+
+// HTTPHandler returns a handler which serves the latest measurement as JSON.
+// A new measurement is taken if the last one is older than maxAge.
+// If that fails, the last measurement is served and marked as stale.
+// Responds with 503 Service Unavailable if no measurement is available at all.
+func (tsl *TSL2591) HTTPHandler(maxAge time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		m := tsl.LastMeasurement()
+		stale := false
+		if m.Timestamp.IsZero() || time.Since(m.Timestamp) > maxAge {
+			fresh, err := tsl.MeasureContext(r.Context())
+			if err == nil {
+				m = fresh
+			} else if m.Timestamp.IsZero() {
+				http.Error(w, fmt.Sprintf("failed to measure: %v", err), http.StatusServiceUnavailable)
+				return
+			} else {
+				stale = true
+			}
+		}
+
+		age := time.Since(m.Timestamp)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Last-Modified", m.Timestamp.UTC().Format(http.TimeFormat))
+		if stale {
+			w.Header().Set("Cache-Control", "no-cache")
+		} else {
+			remaining := math.Max(0, (maxAge - age).Seconds())
+			w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(remaining)))
+		}
+		if r.Method == http.MethodHead {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(HTTPMeasurement{
+			Measurement: m,
+			Age:         age.Seconds(),
+			Stale:       stale,
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package tsl2591
+
+import "fmt"
+
+// PackageID returns the package identification, i.e. bits 5:4 of RegisterPackagePID.
+// This is 0 for all packages documented by ams.
+func (tsl *TSL2591) PackageID() (byte, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	pid, err := tsl.readU8(RegisterPackagePID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read package ID: %w", err)
+	}
+	return (pid >> 4) & 0b11, nil
+}
+
+// DeviceID returns the device identification as read from RegisterDeviceID.
+// This is DeviceID for a TSL2591.
+func (tsl *TSL2591) DeviceID() (byte, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	id, err := tsl.readU8(RegisterDeviceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read device ID: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,119 @@
+package tsl2591
+
+import "fmt"
+
+// SetALSThresholds sets the low and high ALS interrupt thresholds.
+// The ALS interrupt is raised when channel 0 leaves the band [low, high],
+// subject to the persist filter.
+func (tsl *TSL2591) SetALSThresholds(low, high uint16) error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if err := tsl.writeU16(RegisterThresholdAILTL, low); err != nil {
+		return fmt.Errorf("failed to write ALS low threshold: %w", err)
+	}
+	if err := tsl.writeU16(RegisterThresholdAIHTL, high); err != nil {
+		return fmt.Errorf("failed to write ALS high threshold: %w", err)
+	}
+	return nil
+}
+
+// GetALSThresholds returns the low and high ALS interrupt thresholds
+func (tsl *TSL2591) GetALSThresholds() (low, high uint16, err error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	low, err = tsl.readU16(RegisterThresholdAILTL)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read ALS low threshold: %w", err)
+	}
+	high, err = tsl.readU16(RegisterThresholdAIHTL)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read ALS high threshold: %w", err)
+	}
+	return low, high, nil
+}
+
+// SetPersistFilter sets the number of consecutive out-of-range ALS cycles
+// required before an ALS interrupt is generated
+func (tsl *TSL2591) SetPersistFilter(persist Persist) error {
+	if !persist.valid() {
+		return fmt.Errorf("%w: 0x%02x", ErrInvalidPersist, byte(persist))
+	}
+
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	// Get persist filter
+	filter, err := tsl.readU8(RegisterPersistFilter)
+	if err != nil {
+		return fmt.Errorf("failed to read current persist filter: %w", err)
+	}
+
+	// Update persist filter
+	filter &= 0b11110000
+	filter |= byte(persist)
+
+	// Write persist filter
+	if err = tsl.writeU8(RegisterPersistFilter, filter); err != nil {
+		return fmt.Errorf("failed to write persist filter: %w", err)
+	}
+	return nil
+}
+
+// GetPersistFilter returns the current ALS interrupt persist filter
+func (tsl *TSL2591) GetPersistFilter() (Persist, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	filter, err := tsl.readU8(RegisterPersistFilter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read persist filter: %w", err)
+	}
+	return Persist(filter & 0b00001111), nil
+}
+
+// ClearALSInterrupt clears a pending ALS interrupt
+func (tsl *TSL2591) ClearALSInterrupt() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if err := tsl.writeSpecial(ClearALSInt); err != nil {
+		return fmt.Errorf("failed to clear ALS interrupt: %w", err)
+	}
+	return nil
+}
+
+// ClearNoPersistInterrupt clears a pending no-persist ALS interrupt
+func (tsl *TSL2591) ClearNoPersistInterrupt() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if err := tsl.writeSpecial(ClearNoPersistInt); err != nil {
+		return fmt.Errorf("failed to clear no-persist interrupt: %w", err)
+	}
+	return nil
+}
+
+// ClearAllInterrupts clears both pending ALS and no-persist ALS interrupts
+func (tsl *TSL2591) ClearAllInterrupts() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if err := tsl.writeSpecial(ClearInt); err != nil {
+		return fmt.Errorf("failed to clear interrupts: %w", err)
+	}
+	return nil
+}
+
+// ForceInterrupt forces an interrupt, which is useful to test
+// the wiring of the INT pin and the interrupt handler
+func (tsl *TSL2591) ForceInterrupt() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if err := tsl.writeSpecial(TestInt); err != nil {
+		return fmt.Errorf("failed to force interrupt: %w", err)
+	}
+	return nil
+}
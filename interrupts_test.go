@@ -0,0 +1,69 @@
+package tsl2591
+
+import (
+	"errors"
+	"testing"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+func TestALSThresholds(t *testing.T) {
+	tsl := newTestSensor(t, nil,
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAILTL, 0x34, 0x12}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAIHTL, 0xcd, 0xab}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAILTL}, R: []byte{0x34, 0x12}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAIHTL}, R: []byte{0xcd, 0xab}},
+	)
+
+	if err := tsl.SetALSThresholds(0x1234, 0xabcd); err != nil {
+		t.Fatalf("SetALSThresholds failed: %v", err)
+	}
+	low, high, err := tsl.GetALSThresholds()
+	if err != nil {
+		t.Fatalf("GetALSThresholds failed: %v", err)
+	}
+	if low != 0x1234 || high != 0xabcd {
+		t.Errorf("expected thresholds 0x1234-0xabcd, got 0x%04x-0x%04x", low, high)
+	}
+}
+
+func TestPersistFilter(t *testing.T) {
+	// The reserved upper nibble is preserved
+	tsl := newTestSensor(t, nil,
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterPersistFilter}, R: []byte{0x30}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterPersistFilter, 0x30 | byte(Persist5)}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterPersistFilter}, R: []byte{0x30 | byte(Persist5)}},
+	)
+
+	if err := tsl.SetPersistFilter(Persist5); err != nil {
+		t.Fatalf("SetPersistFilter failed: %v", err)
+	}
+	persist, err := tsl.GetPersistFilter()
+	if err != nil {
+		t.Fatalf("GetPersistFilter failed: %v", err)
+	}
+	if persist != Persist5 {
+		t.Errorf("expected %v, got %v", Persist5, persist)
+	}
+}
+
+func TestForceInterrupt(t *testing.T) {
+	tsl := newTestSensor(t, nil,
+		i2ctest.IO{Addr: Addr, W: []byte{TestInt}},
+		i2ctest.IO{Addr: Addr, W: []byte{ClearInt}},
+	)
+
+	if err := tsl.ForceInterrupt(); err != nil {
+		t.Fatalf("ForceInterrupt failed: %v", err)
+	}
+	if err := tsl.ClearAllInterrupts(); err != nil {
+		t.Fatalf("ClearAllInterrupts failed: %v", err)
+	}
+}
+
+func TestPersistFilterInvalid(t *testing.T) {
+	tsl := newTestSensor(t, nil)
+	if err := tsl.SetPersistFilter(Persist(0x10)); !errors.Is(err, ErrInvalidPersist) {
+		t.Fatalf("expected ErrInvalidPersist, got %v", err)
+	}
+}
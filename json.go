@@ -0,0 +1,321 @@
+package tsl2591
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MarshalJSON implements json.Marshaler, e.g. "GainMed"
+func (g Gain) MarshalJSON() ([]byte, error) {
+	if !g.valid() {
+		return nil, fmt.Errorf("%w: 0x%02x", ErrInvalidGain, byte(g))
+	}
+	return json.Marshal(strings.Fields(g.String())[0])
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Accepts all values supported by ParseGain, either as string or number. Null is ignored.
+func (g *Gain) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	gain, err := ParseGain(s)
+	if err != nil {
+		return err
+	}
+	*g = gain
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, e.g. "300ms"
+func (t IntegrationTime) MarshalJSON() ([]byte, error) {
+	if !t.valid() {
+		return nil, fmt.Errorf("%w: 0x%02x", ErrInvalidTiming, byte(t))
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Accepts all values supported by ParseIntegrationTime, either as string or number. Null is ignored.
+func (t *IntegrationTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	timing, err := ParseIntegrationTime(s)
+	if err != nil {
+		return err
+	}
+	*t = timing
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, e.g. "Persist5"
+func (p Persist) MarshalJSON() ([]byte, error) {
+	if !p.valid() {
+		return nil, fmt.Errorf("%w: 0x%02x", ErrInvalidPersist, byte(p))
+	}
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Accepts all values supported by ParsePersist, either as string or number. Null is ignored.
+func (p *Persist) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	persist, err := ParsePersist(s)
+	if err != nil {
+		return err
+	}
+	*p = persist
+	return nil
+}
+
+// luxMethodNames are the names of the lux methods without prefix "LuxMethod"
+var luxMethodNames = []string{"CircuitPython", "Arduino", "AMS"}
+
+// MarshalJSON implements json.Marshaler, e.g. "LuxMethodArduino"
+func (m LuxMethod) MarshalJSON() ([]byte, error) {
+	return marshalName("LuxMethod", luxMethodNames, "lux method", byte(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The prefix "LuxMethod" is optional and names are case-insensitive. Null is ignored.
+func (m *LuxMethod) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value, err := unmarshalName(data, "LuxMethod", luxMethodNames, "lux method")
+	if err != nil {
+		return err
+	}
+	*m = LuxMethod(value)
+	return nil
+}
+
+// overflowPolicyNames are the names of the overflow policies without prefix "Overflow"
+var overflowPolicyNames = []string{"ReturnError", "ClampToMax", "AutoReduceGainAndRetry"}
+
+// MarshalJSON implements json.Marshaler, e.g. "OverflowClampToMax"
+func (p OverflowPolicy) MarshalJSON() ([]byte, error) {
+	return marshalName("Overflow", overflowPolicyNames, "overflow policy", byte(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The prefix "Overflow" is optional and names are case-insensitive. Null is ignored.
+func (p *OverflowPolicy) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value, err := unmarshalName(data, "Overflow", overflowPolicyNames, "overflow policy")
+	if err != nil {
+		return err
+	}
+	*p = OverflowPolicy(value)
+	return nil
+}
+
+// outlierRejectionNames are the names of the outlier rejections without prefix "OutlierRejection"
+var outlierRejectionNames = []string{"None", "MAD", "SigmaClip"}
+
+// MarshalJSON implements json.Marshaler, e.g. "OutlierRejectionMAD"
+func (o OutlierRejection) MarshalJSON() ([]byte, error) {
+	return marshalName("OutlierRejection", outlierRejectionNames, "outlier rejection", byte(o))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The prefix "OutlierRejection" is optional and names are case-insensitive. Null is ignored.
+func (o *OutlierRejection) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value, err := unmarshalName(data, "OutlierRejection", outlierRejectionNames, "outlier rejection")
+	if err != nil {
+		return err
+	}
+	*o = OutlierRejection(value)
+	return nil
+}
+
+// optsJSON is the JSON representation of Opts.
+// Logger and InterruptPin can't be represented and are left out.
+type optsJSON struct {
+	Bus                 string           `json:"bus"`
+	Address             uint16           `json:"address"`
+	SkipHostInit        bool             `json:"skip_host_init"`
+	Gain                Gain             `json:"gain"`
+	Timing              IntegrationTime  `json:"timing"`
+	SleepAfterInterrupt bool             `json:"sleep_after_interrupt"`
+	AutoGain            bool             `json:"auto_gain"`
+	AutoRange           *autoRangeJSON   `json:"auto_range,omitempty"`
+	RecoverAfter        int              `json:"recover_after"`
+	ReopenBus           bool             `json:"reopen_bus"`
+	StuckAfter          int              `json:"stuck_after"`
+	ResetWhenStuck      bool             `json:"reset_when_stuck"`
+	LuxCoefficients     LuxCoefficients  `json:"lux_coefficients"`
+	LuxMethod           LuxMethod        `json:"lux_method"`
+	SkyBrightnessOffset float64          `json:"sky_brightness_offset"`
+	OverflowPolicy      OverflowPolicy   `json:"overflow_policy"`
+	SmoothingAlpha      float64          `json:"smoothing_alpha"`
+	OutlierRejection    OutlierRejection `json:"outlier_rejection"`
+	OutlierThreshold    float64          `json:"outlier_threshold"`
+	StatsWindow         duration         `json:"stats_window"`
+	Trace               bool             `json:"trace"`
+}
+
+// autoRangeJSON is the JSON representation of AutoRange
+type autoRangeJSON struct {
+	Low      float64  `json:"low"`
+	High     float64  `json:"high"`
+	Settle   duration `json:"settle"`
+	GainOnly bool     `json:"gain_only"`
+}
+
+// MarshalJSON implements json.Marshaler. Enums are represented by their names
+// and durations as strings like "1m30s". Logger and InterruptPin are left out.
+func (o Opts) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newOptsJSON(o))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Fields missing in the JSON keep
+// their current value, so defaults can be applied by unmarshaling into DefaultOptions.
+func (o *Opts) UnmarshalJSON(data []byte) error {
+	aux := newOptsJSON(*o)
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	o.Bus = aux.Bus
+	o.Address = aux.Address
+	o.SkipHostInit = aux.SkipHostInit
+	o.Gain = aux.Gain
+	o.Timing = aux.Timing
+	o.SleepAfterInterrupt = aux.SleepAfterInterrupt
+	o.AutoGain = aux.AutoGain
+	o.AutoRange = nil
+	if aux.AutoRange != nil {
+		o.AutoRange = &AutoRange{
+			Low:      aux.AutoRange.Low,
+			High:     aux.AutoRange.High,
+			Settle:   time.Duration(aux.AutoRange.Settle),
+			GainOnly: aux.AutoRange.GainOnly,
+		}
+	}
+	o.RecoverAfter = aux.RecoverAfter
+	o.ReopenBus = aux.ReopenBus
+	o.StuckAfter = aux.StuckAfter
+	o.ResetWhenStuck = aux.ResetWhenStuck
+	o.LuxCoefficients = aux.LuxCoefficients
+	o.LuxMethod = aux.LuxMethod
+	o.SkyBrightnessOffset = aux.SkyBrightnessOffset
+	o.OverflowPolicy = aux.OverflowPolicy
+	o.SmoothingAlpha = aux.SmoothingAlpha
+	o.OutlierRejection = aux.OutlierRejection
+	o.OutlierThreshold = aux.OutlierThreshold
+	o.StatsWindow = time.Duration(aux.StatsWindow)
+	o.Trace = aux.Trace
+	return nil
+}
+
+// newOptsJSON returns the JSON representation of the options
+func newOptsJSON(o Opts) optsJSON {
+	aux := optsJSON{
+		Bus:                 o.Bus,
+		Address:             o.Address,
+		SkipHostInit:        o.SkipHostInit,
+		Gain:                o.Gain,
+		Timing:              o.Timing,
+		SleepAfterInterrupt: o.SleepAfterInterrupt,
+		AutoGain:            o.AutoGain,
+		RecoverAfter:        o.RecoverAfter,
+		ReopenBus:           o.ReopenBus,
+		StuckAfter:          o.StuckAfter,
+		ResetWhenStuck:      o.ResetWhenStuck,
+		LuxCoefficients:     o.LuxCoefficients,
+		LuxMethod:           o.LuxMethod,
+		SkyBrightnessOffset: o.SkyBrightnessOffset,
+		OverflowPolicy:      o.OverflowPolicy,
+		SmoothingAlpha:      o.SmoothingAlpha,
+		OutlierRejection:    o.OutlierRejection,
+		OutlierThreshold:    o.OutlierThreshold,
+		StatsWindow:         duration(o.StatsWindow),
+		Trace:               o.Trace,
+	}
+	if o.AutoRange != nil {
+		aux.AutoRange = &autoRangeJSON{
+			Low:      o.AutoRange.Low,
+			High:     o.AutoRange.High,
+			Settle:   duration(o.AutoRange.Settle),
+			GainOnly: o.AutoRange.GainOnly,
+		}
+	}
+	return aux
+}
+
+// duration is a time.Duration which is represented in JSON as a string like "1m30s"
+type duration time.Duration
+
+// MarshalJSON implements json.Marshaler
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid duration %s: %w", data, err)
+	}
+	value, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(value)
+	return nil
+}
+
+// unquote returns the content of a JSON string or the raw value of other types, e.g. numbers
+func unquote(data []byte) (string, error) {
+	if len(data) == 0 || data[0] != '"' {
+		return string(data), nil
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	return s, err
+}
+
+// marshalName marshals the value of an enum as its prefixed name
+func marshalName(prefix string, names []string, kind string, value byte) ([]byte, error) {
+	if int(value) >= len(names) {
+		return nil, fmt.Errorf("%w: unknown %s %d", ErrInvalidConfig, kind, value)
+	}
+	return json.Marshal(prefix + names[value])
+}
+
+// unmarshalName returns the value of an enum from its name, case-insensitive and with optional prefix
+func unmarshalName(data []byte, prefix string, names []string, kind string) (byte, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return 0, fmt.Errorf("%w: invalid %s %s", ErrInvalidConfig, kind, data)
+	}
+	name := strings.TrimPrefix(strings.ToLower(s), strings.ToLower(prefix))
+	for value, candidate := range names {
+		if name == strings.ToLower(candidate) {
+			return byte(value), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown %s %q", ErrInvalidConfig, kind, s)
+}
@@ -0,0 +1,149 @@
+package tsl2591
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEnumJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{value: GainLow, expected: `"GainLow"`},
+		{value: GainMax, expected: `"GainMax"`},
+		{value: IntegrationTime300MS, expected: `"300ms"`},
+		{value: Persist5, expected: `"Persist5"`},
+		{value: LuxMethodArduino, expected: `"LuxMethodArduino"`},
+		{value: OverflowClampToMax, expected: `"OverflowClampToMax"`},
+		{value: OutlierRejectionMAD, expected: `"OutlierRejectionMAD"`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.value)
+		if err != nil {
+			t.Errorf("failed to marshal %v: %v", tt.value, err)
+			continue
+		}
+		if string(data) != tt.expected {
+			t.Errorf("expected %v to marshal to %s, got %s", tt.value, tt.expected, data)
+		}
+
+		decoded := reflect.New(reflect.TypeOf(tt.value))
+		if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+			t.Errorf("failed to unmarshal %s: %v", data, err)
+			continue
+		}
+		if decoded.Elem().Interface() != tt.value {
+			t.Errorf("expected %s to unmarshal to %v, got %v", data, tt.value, decoded.Elem().Interface())
+		}
+	}
+}
+
+func TestEnumUnmarshalJSON(t *testing.T) {
+	var gain Gain
+	var timing IntegrationTime
+	var persist Persist
+	var method LuxMethod
+	var policy OverflowPolicy
+	var rejection OutlierRejection
+
+	tests := []struct {
+		data     string
+		target   any
+		expected any
+	}{
+		{data: `428`, target: &gain, expected: GainHigh},
+		{data: `"medium"`, target: &gain, expected: GainMed},
+		{data: `200`, target: &timing, expected: IntegrationTime200MS},
+		{data: `"0.6s"`, target: &timing, expected: IntegrationTime600MS},
+		{data: `60`, target: &persist, expected: Persist60},
+		{data: `"ams"`, target: &method, expected: LuxMethodAMS},
+		{data: `"autoreducegainandretry"`, target: &policy, expected: OverflowAutoReduceGainAndRetry},
+		{data: `"SigmaClip"`, target: &rejection, expected: OutlierRejectionSigmaClip},
+		{data: `null`, target: &gain, expected: GainMed},
+		{data: `null`, target: &timing, expected: IntegrationTime600MS},
+		{data: `null`, target: &persist, expected: Persist60},
+		{data: `null`, target: &method, expected: LuxMethodAMS},
+		{data: `null`, target: &policy, expected: OverflowAutoReduceGainAndRetry},
+		{data: `null`, target: &rejection, expected: OutlierRejectionSigmaClip},
+	}
+	for _, tt := range tests {
+		if err := json.Unmarshal([]byte(tt.data), tt.target); err != nil {
+			t.Errorf("failed to unmarshal %s: %v", tt.data, err)
+			continue
+		}
+		if value := reflect.ValueOf(tt.target).Elem().Interface(); value != tt.expected {
+			t.Errorf("expected %s to unmarshal to %v, got %v", tt.data, tt.expected, value)
+		}
+	}
+}
+
+func TestEnumJSONInvalid(t *testing.T) {
+	var gain Gain
+	var timing IntegrationTime
+	var persist Persist
+	var method LuxMethod
+
+	tests := []struct {
+		data     string
+		target   any
+		expected error
+	}{
+		{data: `"huge"`, target: &gain, expected: ErrInvalidGain},
+		{data: `700`, target: &timing, expected: ErrInvalidTiming},
+		{data: `"never"`, target: &persist, expected: ErrInvalidPersist},
+		{data: `"magic"`, target: &method, expected: ErrInvalidConfig},
+		{data: `1`, target: &method, expected: ErrInvalidConfig},
+	}
+	for _, tt := range tests {
+		if err := json.Unmarshal([]byte(tt.data), tt.target); !errors.Is(err, tt.expected) {
+			t.Errorf("expected %s to fail with %v, got %v", tt.data, tt.expected, err)
+		}
+	}
+
+	for _, value := range []any{Gain(0x40), IntegrationTime(0x06), Persist(0x10), LuxMethod(42)} {
+		if _, err := json.Marshal(value); err == nil {
+			t.Errorf("expected marshaling invalid %#v to fail", value)
+		}
+	}
+}
+
+func TestOptsJSONRoundTrip(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Bus = "/dev/i2c-1"
+	opts.Gain = GainHigh
+	opts.Timing = IntegrationTime400MS
+	opts.AutoRange = &AutoRange{Low: 0.2, High: 0.8, Settle: 50 * time.Millisecond}
+	opts.LuxMethod = LuxMethodAMS
+	opts.OutlierRejection = OutlierRejectionMAD
+	opts.StatsWindow = 90 * time.Second
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatalf("failed to marshal options: %v", err)
+	}
+	var decoded Opts
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(&decoded, opts) {
+		t.Errorf("expected %+v, got %+v", opts, decoded)
+	}
+}
+
+func TestOptsUnmarshalJSONPartial(t *testing.T) {
+	opts := DefaultOptions()
+	if err := json.Unmarshal([]byte(`{"timing": "600ms", "stats_window": "1m"}`), opts); err != nil {
+		t.Fatalf("failed to unmarshal options: %v", err)
+	}
+
+	expected := DefaultOptions()
+	expected.Timing = IntegrationTime600MS
+	expected.StatsWindow = time.Minute
+	if !reflect.DeepEqual(opts, expected) {
+		t.Errorf("expected %+v, got %+v", expected, opts)
+	}
+}
@@ -3,13 +3,14 @@ package tsl2591
 import (
 	"encoding/binary"
 	"fmt"
+	"time"
 )
 
 // readU8 reads an 8-bit unsigned value from the specified 8-bit address.
 func (tsl *TSL2591) readU8(address byte) (uint8, error) {
 	readBuffer := make([]byte, 1)
 	cmd := []byte{CommandBit | address}
-	if err := tsl.dev.Tx(cmd, readBuffer); err != nil {
+	if err := tsl.tx(cmd, readBuffer); err != nil {
 		return 0, fmt.Errorf("failed to read uint8: %w", err)
 	}
 	return readBuffer[0], nil
@@ -21,7 +22,7 @@ func (tsl *TSL2591) writeU8(address, value byte) error {
 		CommandBit | address,
 		value,
 	}
-	if _, err := tsl.dev.Write(data); err != nil {
+	if err := tsl.tx(data, nil); err != nil {
 		return fmt.Errorf("failed to write uint8 %x to address %x: %w", value, address, err)
 	}
 	return nil
@@ -31,8 +32,77 @@ func (tsl *TSL2591) writeU8(address, value byte) error {
 func (tsl *TSL2591) readU16(address byte) (uint16, error) {
 	readBuffer := make([]byte, 2)
 	cmd := []byte{CommandBit | address}
-	if err := tsl.dev.Tx(cmd, readBuffer); err != nil {
+	if err := tsl.tx(cmd, readBuffer); err != nil {
 		return 0, fmt.Errorf("failed to read uint16: %w", err)
 	}
 	return binary.LittleEndian.Uint16(readBuffer), nil
 }
+
+// writeU16 writes a 16-bit little-endian unsigned value to the specified 8-bit address
+func (tsl *TSL2591) writeU16(address byte, value uint16) error {
+	data := make([]byte, 3)
+	data[0] = CommandBit | address
+	binary.LittleEndian.PutUint16(data[1:], value)
+	if err := tsl.tx(data, nil); err != nil {
+		return fmt.Errorf("failed to write uint16 %x to address %x: %w", value, address, err)
+	}
+	return nil
+}
+
+// writeSpecial issues a special function command, e.g. ClearInt
+func (tsl *TSL2591) writeSpecial(command byte) error {
+	if err := tsl.tx([]byte{command}, nil); err != nil {
+		return fmt.Errorf("failed to write special function %x: %w", command, err)
+	}
+	return nil
+}
+
+// readBlock reads length bytes in a single transaction, starting at the specified 8-bit address
+func (tsl *TSL2591) readBlock(address byte, length int) ([]byte, error) {
+	readBuffer := make([]byte, length)
+	cmd := []byte{CommandBit | address}
+	if err := tsl.tx(cmd, readBuffer); err != nil {
+		return nil, fmt.Errorf("failed to read %d bytes: %w", length, err)
+	}
+	return readBuffer, nil
+}
+
+// tx performs a single transaction on the bus. If tracing is enabled,
+// the transaction is logged at debug level.
+func (tsl *TSL2591) tx(w, r []byte) error {
+	if tsl.closed {
+		return ErrClosed
+	}
+	if !tsl.opts.Trace {
+		return tsl.countErrors(r, tsl.dev.Tx(w, r))
+	}
+
+	// Special function commands don't address a register
+	address := "none"
+	if w[0]&0b11100000 == CommandBit {
+		address = fmt.Sprintf("0x%02x", w[0]&0b00011111)
+	}
+
+	start := time.Now()
+	err := tsl.dev.Tx(w, r)
+	tsl.opts.Logger.Debug("TSL2591: I2C transaction",
+		"command", fmt.Sprintf("0x%02x", w[0]),
+		"address", address,
+		"write", fmt.Sprintf("%x", w[1:]),
+		"read", fmt.Sprintf("%x", r),
+		"duration", time.Since(start),
+		"error", err)
+	return tsl.countErrors(r, err)
+}
+
+// countErrors updates the error metrics if the transaction failed and returns the error
+func (tsl *TSL2591) countErrors(r []byte, err error) error {
+	if err != nil {
+		if len(r) > 0 {
+			tsl.metrics.readErrors.Add(1)
+		} else {
+			tsl.metrics.writeErrors.Add(1)
+		}
+	}
+	return err
+}
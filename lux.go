@@ -0,0 +1,104 @@
+package tsl2591
+
+import "math"
+
+// LuxCoefficients holds the coefficients of the lux calculation.
+// See the constants LuxDF, LuxCoefB, LuxCoefC and LuxCoefD for their meaning.
+type LuxCoefficients struct {
+	DF float64 `json:"df"`
+	B  float64 `json:"b"`
+	C  float64 `json:"c"`
+	D  float64 `json:"d"`
+}
+
+// LuxMethod selects the equation used to calculate lux from the raw channel counts.
+// The equations diverge significantly for light with a high IR content.
+type LuxMethod byte
+
+const (
+	// LuxMethodCircuitPython is the default equation, as used by the Adafruit CircuitPython library.
+	// It takes the maximum of two linear equations using all LuxCoefficients.
+	LuxMethodCircuitPython LuxMethod = iota
+
+	// LuxMethodArduino is the equation used by the Adafruit_TSL2591_Library,
+	// which scales the visible counts by the visible fraction of the total.
+	// Only LuxCoefficients.DF is used.
+	LuxMethodArduino
+
+	// LuxMethodAMS is the single linear equation suggested by ams,
+	// subtracting LuxCoefAMS times the IR counts from the full spectrum counts.
+	// Only LuxCoefficients.DF is used.
+	LuxMethodAMS
+)
+
+// LuxCoefAMS is the channel 1 coefficient of LuxMethodAMS
+const LuxCoefAMS float64 = 1.7
+
+// DefaultLuxCoefficients returns the default coefficients of the lux calculation
+func DefaultLuxCoefficients() LuxCoefficients {
+	return LuxCoefficients{
+		DF: LuxDF,
+		B:  LuxCoefB,
+		C:  LuxCoefC,
+		D:  LuxCoefD,
+	}
+}
+
+// calculateLux calculates a lux value from the raw channel counts using the current settings.
+// Overflow is checked on the raw counts, before subtracting the dark offset.
+func (tsl *TSL2591) calculateLux(c0, c1 uint16) (float64, error) {
+	// Handle overflow.
+	maxCounts := tsl.maxCounts()
+	if c0 >= maxCounts || c1 >= maxCounts {
+		tsl.metrics.overflows.Add(1)
+		channel := FullSpectrum
+		if c0 < maxCounts {
+			channel = Infrared
+		}
+		return 0, SaturationError{Channel: channel, Chan0: c0, Chan1: c1, Gain: tsl.gain, Timing: tsl.timing}
+	}
+	c0, c1 = tsl.correctDark(c0, c1)
+	return tsl.luxFromCounts(float64(c0), float64(c1)), nil
+}
+
+// maxLux returns the largest lux value which can be measured with the current settings
+func (tsl *TSL2591) maxLux() float64 {
+	return tsl.luxFromCounts(float64(tsl.maxCounts()), 0)
+}
+
+// luxFromCounts applies the lux equation to the provided counts using the current settings
+func (tsl *TSL2591) luxFromCounts(ch0, ch1 float64) float64 {
+	// Compute the atime in milliseconds
+	atime := float64(tsl.timing.Duration().Milliseconds())
+
+	// Calculate lux
+	again := tsl.gain.Multiplier()
+	coef := tsl.opts.LuxCoefficients
+	cpl := (atime * again) / coef.DF
+	switch tsl.opts.LuxMethod {
+	case LuxMethodArduino:
+		if ch0 == 0 {
+			return 0
+		}
+		return (ch0 - ch1) * (1 - (ch1 / ch0)) / cpl
+	case LuxMethodAMS:
+		return (ch0 - (LuxCoefAMS * ch1)) / cpl
+	default:
+		lux1 := (ch0 - (coef.B * ch1)) / cpl
+		lux2 := ((coef.C * ch0) - (coef.D * ch1)) / cpl
+		return math.Max(lux1, lux2)
+	}
+}
+
+// maxCounts returns the maximum sensor counts based on the integration time (atime) setting
+func (tsl *TSL2591) maxCounts() uint16 {
+	return maxCounts(tsl.timing)
+}
+
+// maxCounts returns the maximum sensor counts for the provided integration time
+func maxCounts(timing IntegrationTime) uint16 {
+	if timing == IntegrationTime100MS {
+		return MaxCount100ms
+	}
+	return MaxCount
+}
@@ -0,0 +1,166 @@
+package tsl2591
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LabeledMeasurement is a measurement of one of the sensors of a Manager
+type LabeledMeasurement struct {
+	// Label identifies the sensor
+	Label string `json:"label"`
+
+	Measurement
+}
+
+// Manager owns multiple sensors, e.g. on different buses or mux channels,
+// and measures them on a shared schedule. It is safe for concurrent use.
+//
+// Sensors are measured one after the other, so sensors behind a mux
+// never access the bus concurrently through the Manager.
+type Manager struct {
+	mu      sync.Mutex
+	labels  []string
+	sensors map[string]*TSL2591
+}
+
+// NewManager creates a new manager without sensors
+func NewManager() *Manager {
+	return &Manager{sensors: make(map[string]*TSL2591)}
+}
+
+// Add adds a sensor with a unique label. The manager takes ownership of the sensor,
+// so it is closed by Close.
+func (m *Manager) Add(label string, sensor *TSL2591) error {
+	if sensor == nil {
+		return fmt.Errorf("sensor with label %q is nil", label)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sensors[label]; ok {
+		return fmt.Errorf("sensor with label %q already exists", label)
+	}
+	m.labels = append(m.labels, label)
+	m.sensors[label] = sensor
+	return nil
+}
+
+// Sensor returns the sensor with the provided label
+func (m *Manager) Sensor(label string) (*TSL2591, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sensor, ok := m.sensors[label]
+	return sensor, ok
+}
+
+// Labels returns the labels of all sensors in the order they were added
+func (m *Manager) Labels() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.labels...)
+}
+
+// MeasureAll measures all sensors in the order they were added.
+// Failed measurements are returned with Err set, so a single failing sensor
+// doesn't prevent reading the others.
+func (m *Manager) MeasureAll(ctx context.Context) []LabeledMeasurement {
+	m.mu.Lock()
+	labels := append([]string(nil), m.labels...)
+	sensors := make([]*TSL2591, len(labels))
+	for i, label := range labels {
+		sensors[i] = m.sensors[label]
+	}
+	m.mu.Unlock()
+
+	measurements := make([]LabeledMeasurement, 0, len(labels))
+	for i, sensor := range sensors {
+		measurement, err := sensor.MeasureContext(ctx)
+		if err != nil {
+			measurement = Measurement{Err: err}
+		}
+		measurements = append(measurements, LabeledMeasurement{Label: labels[i], Measurement: measurement})
+	}
+	return measurements
+}
+
+// PollContinuous measures all sensors every interval in a managed goroutine and
+// emits the measurements on the returned channel, see MeasureAll.
+// A non-positive interval polls every longest integration time of the sensors.
+// Call the returned function to stop polling, after which the channel is closed.
+func (m *Manager) PollContinuous(interval time.Duration) (<-chan []LabeledMeasurement, func()) {
+	if interval <= 0 {
+		interval = m.longestIntegrationTime()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	measurements := make(chan []LabeledMeasurement)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(measurements)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			all := m.MeasureAll(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case measurements <- all:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+	return measurements, stop
+}
+
+// longestIntegrationTime returns the longest integration time of all sensors,
+// or the shortest supported integration time if the manager has no sensors
+func (m *Manager) longestIntegrationTime() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	longest := IntegrationTime100MS.Duration()
+	for _, sensor := range m.sensors {
+		if d := sensor.GetTiming().Duration(); d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+// Close closes all sensors and removes them from the manager
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var errs []error
+	for _, label := range m.labels {
+		if err := m.sensors[label].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close sensor %q: %w", label, err))
+		}
+	}
+	m.labels = nil
+	m.sensors = make(map[string]*TSL2591)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,129 @@
+package tsl2591
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Measurement holds a single reading of the sensor together with
+// the settings which were effective when it was taken.
+// All values are derived from the same integration cycle.
+type Measurement struct {
+	// Timestamp is the time at which the channels were read
+	Timestamp time.Time `json:"timestamp"`
+
+	// Lux is the calculated lux value
+	Lux float64 `json:"lux"`
+
+	// Visible is the visible value, see Visible
+	Visible uint32 `json:"visible"`
+
+	// Infrared is the infrared value, see Infrared
+	Infrared uint16 `json:"infrared"`
+
+	// FullSpectrum is the full spectrum value, see FullSpectrum
+	FullSpectrum uint32 `json:"full_spectrum"`
+
+	// Chan0 is the raw count of channel 0 (IR + visible)
+	Chan0 uint16 `json:"chan0"`
+
+	// Chan1 is the raw count of channel 1 (IR only)
+	Chan1 uint16 `json:"chan1"`
+
+	// Gain is the gain used for this reading
+	Gain Gain `json:"gain"`
+
+	// Timing is the integration time used for this reading
+	Timing IntegrationTime `json:"timing"`
+
+	// Saturated is set when a channel overflowed and Lux was
+	// clamped to the maximum, see OverflowClampToMax
+	Saturated bool `json:"saturated"`
+
+	// Fresh is set when the channels hold a new conversion, i.e. when the raw counts
+	// changed or a full ALS cycle passed since the channels were previously read.
+	// After the ALS cycle was (re)started, e.g. by Enable or a change of the settings,
+	// the first reading is only fresh if the ALS completed a conversion (AVALID).
+	// Otherwise the values repeat those of the previous integration period.
+	Fresh bool `json:"fresh"`
+
+	// Err is set when taking the measurement failed, in which case the other fields are zero.
+	// Only used by SenseContinuous, as the other methods return the error directly.
+	Err error `json:"-"`
+}
+
+// Measure reads both channels once and derives all values from them.
+// This is cheaper than calling Lux, Visible, Infrared and FullSpectrum separately
+// and guarantees all values come from the same integration cycle.
+func (tsl *TSL2591) Measure() (Measurement, error) {
+	return tsl.MeasureContext(context.Background())
+}
+
+// MeasureContext is Measure which honors cancellation and deadlines of the context
+func (tsl *TSL2591) MeasureContext(ctx context.Context) (Measurement, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.measure(ctx)
+}
+
+// measure reads both channels and calculates the lux value,
+// which is smoothed and recorded in the rolling statistics if enabled
+func (tsl *TSL2591) measure(ctx context.Context) (Measurement, error) {
+	m, err := tsl.measureUnsmoothed(ctx)
+	if err != nil {
+		return Measurement{}, err
+	}
+	if tsl.smoothing != nil {
+		m.Lux = tsl.smoothing.Add(m.Lux)
+	}
+	if tsl.stats != nil {
+		tsl.stats.Add(m.Timestamp, m.Lux)
+	}
+	tsl.last = m
+	return m, nil
+}
+
+// measureUnsmoothed reads both channels and calculates the lux value without smoothing
+func (tsl *TSL2591) measureUnsmoothed(ctx context.Context) (Measurement, error) {
+	c0, c1, err := tsl.rawLuminosity(ctx)
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	lux, err := tsl.calculateLux(c0, c1)
+	saturated := false
+	if errors.Is(err, ErrOverflow) {
+		c0, c1, lux, saturated, err = tsl.handleOverflow(ctx, err)
+	}
+	if err != nil {
+		return Measurement{}, err
+	}
+	timestamp := time.Now()
+	c0, c1 = tsl.correctDark(c0, c1)
+	lux = tsl.luxCalibration.Apply(lux)
+
+	return Measurement{
+		Timestamp:    timestamp,
+		Lux:          lux,
+		Visible:      visible(c0, c1),
+		Infrared:     c1,
+		FullSpectrum: fullSpectrum(c0, c1),
+		Chan0:        c0,
+		Chan1:        c1,
+		Gain:         tsl.gain,
+		Timing:       tsl.timing,
+		Saturated:    saturated,
+		Fresh:        tsl.fresh,
+	}, nil
+}
+
+// LastMeasurement returns the most recent successful measurement taken by Measure,
+// Lux or any method built on them, like SenseContinuous. RawLuminosity and the
+// samples of AverageLux and AverageRawLuminosity don't update it.
+// The returned Measurement has a zero Timestamp if no measurement was taken yet.
+func (tsl *TSL2591) LastMeasurement() Measurement {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.last
+}
@@ -0,0 +1,68 @@
+package tsl2591
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+func TestMeasureFresh(t *testing.T) {
+	// The first read after enabling happens before the first conversion completed
+	ops := []i2ctest.IO{{Addr: Addr, W: []byte{CommandBit | RegisterDeviceStatus}, R: []byte{0x00}}}
+	ops = append(ops, readOps(0, 0, false)...)
+	ops = append(ops, readOps(1000, 100, false)...)
+	ops = append(ops, readOps(1000, 100, false)...)
+	ops = append(ops, readOps(1000, 100, false)...)
+	tsl := newTestSensor(t, nil, ops...)
+
+	steps := []struct {
+		name  string
+		age   time.Duration
+		fresh bool
+	}{
+		{name: "before first conversion", fresh: false},
+		{name: "changed counts", fresh: true},
+		{name: "same counts within cycle", fresh: false},
+		{name: "same counts after cycle", age: time.Second, fresh: true},
+	}
+	for _, step := range steps {
+		if step.age > 0 {
+			tsl.lastRead = tsl.lastRead.Add(-step.age)
+		}
+		m, err := tsl.Measure()
+		if err != nil {
+			t.Fatalf("%s: Measure failed: %v", step.name, err)
+		}
+		if m.Fresh != step.fresh {
+			t.Errorf("%s: expected fresh %t, got %t", step.name, step.fresh, m.Fresh)
+		}
+	}
+}
+
+func TestMeasureFreshAfterRestart(t *testing.T) {
+	// Changing the gain restarts the ALS cycle, after which AVALID decides on freshness
+	ops := readOps(1000, 100, true)
+	ops = append(ops, i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainHigh)}})
+	ops = append(ops, restartOps()...)
+	ops = append(ops, readOps(1000, 100, true)...)
+	tsl := newTestSensor(t, nil, ops...)
+
+	if _, err := tsl.Measure(); err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	if err := tsl.SetGain(GainHigh); err != nil {
+		t.Fatalf("SetGain failed: %v", err)
+	}
+	if err := tsl.WaitForData(context.Background()); err != nil {
+		t.Fatalf("WaitForData failed: %v", err)
+	}
+	m, err := tsl.Measure()
+	if err != nil {
+		t.Fatalf("Measure failed: %v", err)
+	}
+	if !m.Fresh {
+		t.Error("expected first reading after restart with AVALID set to be fresh")
+	}
+}
@@ -0,0 +1,51 @@
+package tsl2591
+
+import "sync/atomic"
+
+// Metrics is a snapshot of the driver counters, e.g. for health monitoring
+type Metrics struct {
+	// Reads is the number of successful readings of the light channels
+	Reads uint64
+
+	// ReadErrors is the number of failed read transactions
+	ReadErrors uint64
+
+	// WriteErrors is the number of failed write transactions
+	WriteErrors uint64
+
+	// Overflows is the number of readings with a saturated channel
+	Overflows uint64
+
+	// Retries is the number of re-reads caused by auto ranging or the overflow policy
+	Retries uint64
+
+	// AutoRangeChanges is the number of settings changes by auto ranging or the overflow policy
+	AutoRangeChanges uint64
+
+	// Recoveries is the number of attempts to recover the sensor
+	Recoveries uint64
+}
+
+// metrics holds the driver counters
+type metrics struct {
+	reads            atomic.Uint64
+	readErrors       atomic.Uint64
+	writeErrors      atomic.Uint64
+	overflows        atomic.Uint64
+	retries          atomic.Uint64
+	autoRangeChanges atomic.Uint64
+	recoveries       atomic.Uint64
+}
+
+// Metrics returns a snapshot of the driver counters
+func (tsl *TSL2591) Metrics() Metrics {
+	return Metrics{
+		Reads:            tsl.metrics.reads.Load(),
+		ReadErrors:       tsl.metrics.readErrors.Load(),
+		WriteErrors:      tsl.metrics.writeErrors.Load(),
+		Overflows:        tsl.metrics.overflows.Load(),
+		Retries:          tsl.metrics.retries.Load(),
+		AutoRangeChanges: tsl.metrics.autoRangeChanges.Load(),
+		Recoveries:       tsl.metrics.recoveries.Load(),
+	}
+}
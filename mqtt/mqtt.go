@@ -0,0 +1,226 @@
+// Package mqtt publishes TSL2591 measurements to an MQTT broker.
+//
+// Example:
+//
+//	publisher := mqtt.NewPublisher(sensor, mqtt.Config{
+//		Broker: "tcp://localhost:1883",
+//		Topic:  "home/livingroom/light",
+//	})
+//	log.Fatal(publisher.Run(ctx))
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// DefaultInterval is the publish interval used if Config.Interval is zero
+const DefaultInterval = 10 * time.Second
+
+// DefaultClientID is the client ID used if Config.ClientID is empty
+const DefaultClientID = "tsl2591"
+
+// DefaultTimeout is the timeout used if Config.Timeout is zero
+const DefaultTimeout = 10 * time.Second
+
+// Config holds the settings of a Publisher.
+// Zero values are replaced by the defaults.
+type Config struct {
+	// Broker is the URL of the broker, e.g. "tcp://localhost:1883" or "ssl://broker:8883"
+	Broker string
+
+	// Topic is the topic to publish the measurements on
+	Topic string
+
+	// Interval is the time between two published measurements
+	Interval time.Duration
+
+	// QoS is the MQTT quality of service level (0, 1 or 2)
+	QoS byte
+
+	// Retain makes the broker retain the last measurement for new subscribers
+	Retain bool
+
+	// ClientID is the MQTT client ID
+	ClientID string
+
+	// Username and Password are used to authenticate with the broker if set
+	Username string
+	Password string
+
+	// TLSConfig is used to connect to the broker if set
+	TLSConfig *tls.Config
+
+	// Timeout is the maximum time to wait for connecting and publishing
+	Timeout time.Duration
+
+	// Logger receives failed measurements. Defaults to slog.Default if nil.
+	Logger *slog.Logger
+}
+
+// configJSON is the JSON representation of Config.
+// TLSConfig and Logger can't be represented and the password is never exposed.
+type configJSON struct {
+	Broker   string `json:"broker"`
+	Topic    string `json:"topic"`
+	Interval string `json:"interval,omitempty"`
+	QoS      byte   `json:"qos"`
+	Retain   bool   `json:"retain"`
+	ClientID string `json:"client_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	Timeout  string `json:"timeout,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. Durations are represented
+// as strings like "1m30s". Password, TLSConfig and Logger are left out.
+func (c Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newConfigJSON(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Fields missing in the JSON keep
+// their current value, like Password, TLSConfig and Logger which are never marshaled.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	aux := newConfigJSON(*c)
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var interval, timeout time.Duration
+	var err error
+	if aux.Interval != "" {
+		if interval, err = time.ParseDuration(aux.Interval); err != nil {
+			return fmt.Errorf("invalid interval: %w", err)
+		}
+	}
+	if aux.Timeout != "" {
+		if timeout, err = time.ParseDuration(aux.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+	c.Broker = aux.Broker
+	c.Topic = aux.Topic
+	c.Interval = interval
+	c.QoS = aux.QoS
+	c.Retain = aux.Retain
+	c.ClientID = aux.ClientID
+	c.Username = aux.Username
+	c.Timeout = timeout
+	return nil
+}
+
+// newConfigJSON returns the JSON representation of the config
+func newConfigJSON(c Config) configJSON {
+	aux := configJSON{
+		Broker:   c.Broker,
+		Topic:    c.Topic,
+		QoS:      c.QoS,
+		Retain:   c.Retain,
+		ClientID: c.ClientID,
+		Username: c.Username,
+	}
+	if c.Interval != 0 {
+		aux.Interval = c.Interval.String()
+	}
+	if c.Timeout != 0 {
+		aux.Timeout = c.Timeout.String()
+	}
+	return aux
+}
+
+// Publisher periodically measures the sensor and publishes the measurement as JSON
+type Publisher struct {
+	sensor *tsl2591.TSL2591
+	config Config
+}
+
+// NewPublisher creates a new publisher for the sensor
+func NewPublisher(sensor *tsl2591.TSL2591, config Config) *Publisher {
+	if config.Interval == 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.ClientID == "" {
+		config.ClientID = DefaultClientID
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	return &Publisher{sensor: sensor, config: config}
+}
+
+// Run connects to the broker and publishes a measurement every interval until the context is done.
+// Failed measurements and publishes are logged and skipped.
+func (p *Publisher) Run(ctx context.Context) error {
+	if p.config.Broker == "" || p.config.Topic == "" {
+		return errors.New("broker and topic are required")
+	}
+	if p.config.QoS > 2 {
+		return fmt.Errorf("invalid QoS %d", p.config.QoS)
+	}
+
+	opts := paho.NewClientOptions().
+		AddBroker(p.config.Broker).
+		SetClientID(p.config.ClientID).
+		SetUsername(p.config.Username).
+		SetPassword(p.config.Password).
+		SetAutoReconnect(true)
+	if p.config.TLSConfig != nil {
+		opts.SetTLSConfig(p.config.TLSConfig)
+	}
+	client := paho.NewClient(opts)
+	if err := wait(client.Connect(), p.config.Timeout); err != nil {
+		return fmt.Errorf("failed to connect to broker: %w", err)
+	}
+	defer client.Disconnect(250)
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		m, err := p.sensor.MeasureContext(ctx)
+		if err == nil {
+			// The client reconnects automatically, so a later publish might succeed
+			if err := p.publish(client, m); err != nil {
+				p.config.Logger.Warn("Failed to publish", "error", err)
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		} else {
+			p.config.Logger.Warn("Failed to measure", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish publishes a single measurement
+func (p *Publisher) publish(client paho.Client, m tsl2591.Measurement) error {
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal measurement: %w", err)
+	}
+	if err := wait(client.Publish(p.config.Topic, p.config.QoS, p.config.Retain, payload), p.config.Timeout); err != nil {
+		return fmt.Errorf("failed to publish measurement: %w", err)
+	}
+	return nil
+}
+
+// wait waits for the token to complete and returns its error
+func wait(token paho.Token, timeout time.Duration) error {
+	if !token.WaitTimeout(timeout) {
+		return errors.New("timed out")
+	}
+	return token.Error()
+}
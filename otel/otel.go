@@ -0,0 +1,90 @@
+// Package otel registers the TSL2591 driver as OpenTelemetry instruments.
+//
+// Example:
+//
+//	meter := otel.Meter("github.com/JenswBE/golang-tsl2591")
+//	reg, err := tslotel.Register(sensor, meter)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer reg.Unregister()
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Register creates observable gauges for lux, visible and infrared light
+// and observable counters for the driver metrics on the given meter.
+// A measurement is taken each time the instruments are collected.
+// Call Unregister on the returned registration to stop collecting.
+func Register(sensor *tsl2591.TSL2591, meter metric.Meter) (metric.Registration, error) {
+	lux, err := meter.Float64ObservableGauge("tsl2591.lux",
+		metric.WithDescription("Calculated illuminance"),
+		metric.WithUnit("lx"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lux gauge: %w", err)
+	}
+	visible, err := meter.Int64ObservableGauge("tsl2591.visible",
+		metric.WithDescription("Visible light in counts"),
+		metric.WithUnit("{count}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create visible gauge: %w", err)
+	}
+	infrared, err := meter.Int64ObservableGauge("tsl2591.infrared",
+		metric.WithDescription("Infrared light in counts"),
+		metric.WithUnit("{count}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create infrared gauge: %w", err)
+	}
+	reads, err := meter.Int64ObservableCounter("tsl2591.reads",
+		metric.WithDescription("Number of successful channel readings"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reads counter: %w", err)
+	}
+	busErrors, err := meter.Int64ObservableCounter("tsl2591.bus.errors",
+		metric.WithDescription("Number of failed I2C transactions"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bus errors counter: %w", err)
+	}
+	overflows, err := meter.Int64ObservableCounter("tsl2591.overflows",
+		metric.WithDescription("Number of readings with a saturated channel"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create overflows counter: %w", err)
+	}
+	recoveries, err := meter.Int64ObservableCounter("tsl2591.recoveries",
+		metric.WithDescription("Number of attempts to recover the sensor"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recoveries counter: %w", err)
+	}
+
+	read := metric.WithAttributes(attribute.String("direction", "read"))
+	write := metric.WithAttributes(attribute.String("direction", "write"))
+	callback := func(ctx context.Context, o metric.Observer) error {
+		metrics := sensor.Metrics()
+		o.ObserveInt64(reads, int64(metrics.Reads))
+		o.ObserveInt64(busErrors, int64(metrics.ReadErrors), read)
+		o.ObserveInt64(busErrors, int64(metrics.WriteErrors), write)
+		o.ObserveInt64(overflows, int64(metrics.Overflows))
+		o.ObserveInt64(recoveries, int64(metrics.Recoveries))
+
+		m, err := sensor.MeasureContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to measure: %w", err)
+		}
+		o.ObserveFloat64(lux, m.Lux)
+		o.ObserveInt64(visible, int64(m.Visible))
+		o.ObserveInt64(infrared, int64(m.Infrared))
+		return nil
+	}
+	reg, err := meter.RegisterCallback(callback, lux, visible, infrared, reads, busErrors, overflows, recoveries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register callback: %w", err)
+	}
+	return reg, nil
+}
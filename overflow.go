@@ -0,0 +1,71 @@
+package tsl2591
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// OverflowPolicy defines how Lux and Measure handle saturated channels
+type OverflowPolicy byte
+
+const (
+	// OverflowReturnError returns a SaturationError
+	OverflowReturnError OverflowPolicy = iota
+
+	// OverflowClampToMax returns the largest lux value which can be measured
+	// with the current settings and marks the Measurement as Saturated
+	OverflowClampToMax
+
+	// OverflowAutoReduceGainAndRetry steps the gain down and re-reads the channels
+	// until they are no longer saturated. A SaturationError is returned if
+	// the channels are still saturated at the lowest gain.
+	OverflowAutoReduceGainAndRetry
+)
+
+// handleOverflow applies the overflow policy to a reading which returned overflowErr.
+// Returns the final counts, the lux value and whether it was clamped.
+func (tsl *TSL2591) handleOverflow(ctx context.Context, overflowErr error) (uint16, uint16, float64, bool, error) {
+	var satErr SaturationError
+	if !errors.As(overflowErr, &satErr) {
+		return 0, 0, 0, false, overflowErr
+	}
+
+	switch tsl.opts.OverflowPolicy {
+	case OverflowClampToMax:
+		return satErr.Chan0, satErr.Chan1, tsl.maxLux(), true, nil
+	case OverflowAutoReduceGainAndRetry:
+		for tsl.gain != GainLow {
+			tsl.opts.Logger.Info("TSL2591: overflow, retrying with lower gain",
+				"gain", lowerGain(tsl.gain).String(), "previous_gain", tsl.gain.String())
+			if err := tsl.setGain(lowerGain(tsl.gain)); err != nil {
+				return 0, 0, 0, false, fmt.Errorf("failed to reduce gain after overflow: %w", err)
+			}
+			tsl.metrics.autoRangeChanges.Add(1)
+			if err := tsl.waitForData(ctx); err != nil {
+				return 0, 0, 0, false, fmt.Errorf("failed waiting for data after reducing gain: %w", err)
+			}
+			tsl.metrics.retries.Add(1)
+			c0, c1, err := tsl.readChannels(ctx)
+			if err != nil {
+				return 0, 0, 0, false, err
+			}
+			lux, err := tsl.calculateLux(c0, c1)
+			if !errors.Is(err, ErrOverflow) {
+				return c0, c1, lux, false, err
+			}
+			overflowErr = err
+		}
+	}
+	return 0, 0, 0, false, overflowErr
+}
+
+// lowerGain returns the next lower gain, or GainLow if already lowest
+func lowerGain(gain Gain) Gain {
+	for i := len(gains) - 1; i > 0; i-- {
+		if gains[i] == gain {
+			return gains[i-1]
+		}
+	}
+	return GainLow
+}
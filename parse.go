@@ -0,0 +1,74 @@
+package tsl2591
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseGain parses a gain from its name or multiplier, case-insensitive.
+// Accepted are e.g. "med", "medium", "GainMed", "25" and "25x".
+func ParseGain(s string) (Gain, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	for _, gain := range gains {
+		name := strings.ToLower(strings.Fields(gain.String())[0])
+		short := strings.TrimPrefix(name, "gain")
+		multiplier := strconv.FormatFloat(gain.Multiplier(), 'f', -1, 64)
+		switch value {
+		case name, short, multiplier, multiplier + "x", strings.ToLower(gain.String()):
+			return gain, nil
+		}
+	}
+	if value == "medium" {
+		return GainMed, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidGain, s)
+}
+
+// ParseIntegrationTime parses an integration time from its name or value, case-insensitive.
+// Accepted are e.g. "300ms", "300", "0.3s" and "IntegrationTime300MS".
+func ParseIntegrationTime(s string) (IntegrationTime, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	value = strings.TrimPrefix(value, "integrationtime")
+	if millis, err := strconv.Atoi(value); err == nil {
+		value = strconv.Itoa(millis) + "ms"
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		if timing, err := integrationTimeFromDuration(duration); err == nil {
+			return timing, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidTiming, s)
+}
+
+// integrationTimeFromDuration returns the integration time matching the duration exactly
+func integrationTimeFromDuration(d time.Duration) (IntegrationTime, error) {
+	for _, timing := range timings {
+		if timing.Duration() == d {
+			return timing, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrInvalidTiming, d)
+}
+
+// ParsePersist parses a persist filter from its name or number of cycles, case-insensitive.
+// Accepted are e.g. "every", "any", "Persist5" and "5".
+func ParsePersist(s string) (Persist, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	value = strings.TrimPrefix(value, "persist")
+	switch value {
+	case "every":
+		return PersistEvery, nil
+	case "any":
+		return PersistAny, nil
+	}
+	if cycles, err := strconv.Atoi(value); err == nil {
+		for persist, c := range persistCycles {
+			if c == cycles {
+				return persist, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidPersist, s)
+}
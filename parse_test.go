@@ -0,0 +1,68 @@
+package tsl2591
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseGain(t *testing.T) {
+	tests := map[string]Gain{
+		"low":           GainLow,
+		"MED":           GainMed,
+		"medium":        GainMed,
+		"GainHigh":      GainHigh,
+		"428x":          GainHigh,
+		"9876":          GainMax,
+		" max ":         GainMax,
+		"GainMed (25x)": GainMed,
+	}
+	for input, expected := range tests {
+		gain, err := ParseGain(input)
+		if err != nil || gain != expected {
+			t.Errorf("ParseGain(%q): expected %s, got %s (%v)", input, expected, gain, err)
+		}
+	}
+	if _, err := ParseGain("huge"); !errors.Is(err, ErrInvalidGain) {
+		t.Errorf("expected ErrInvalidGain, got %v", err)
+	}
+}
+
+func TestParseIntegrationTime(t *testing.T) {
+	tests := map[string]IntegrationTime{
+		"100ms":                IntegrationTime100MS,
+		"200":                  IntegrationTime200MS,
+		"0.3s":                 IntegrationTime300MS,
+		"IntegrationTime600MS": IntegrationTime600MS,
+	}
+	for input, expected := range tests {
+		timing, err := ParseIntegrationTime(input)
+		if err != nil || timing != expected {
+			t.Errorf("ParseIntegrationTime(%q): expected %s, got %s (%v)", input, expected, timing, err)
+		}
+	}
+	for _, input := range []string{"150ms", "700ms", "slow"} {
+		if _, err := ParseIntegrationTime(input); !errors.Is(err, ErrInvalidTiming) {
+			t.Errorf("ParseIntegrationTime(%q): expected ErrInvalidTiming, got %v", input, err)
+		}
+	}
+}
+
+func TestParsePersist(t *testing.T) {
+	tests := map[string]Persist{
+		"every":    PersistEvery,
+		"Any":      PersistAny,
+		"Persist5": Persist5,
+		"60":       Persist60,
+	}
+	for input, expected := range tests {
+		persist, err := ParsePersist(input)
+		if err != nil || persist != expected {
+			t.Errorf("ParsePersist(%q): expected %s, got %s (%v)", input, expected, persist, err)
+		}
+	}
+	for _, input := range []string{"4", "61", "never"} {
+		if _, err := ParsePersist(input); !errors.Is(err, ErrInvalidPersist) {
+			t.Errorf("ParsePersist(%q): expected ErrInvalidPersist, got %v", input, err)
+		}
+	}
+}
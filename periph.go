@@ -0,0 +1,79 @@
+//go:build !tinygo
+
+package tsl2591
+
+import (
+	"fmt"
+
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/host/v3"
+)
+
+var (
+	_ conn.Resource = (*TSL2591)(nil)
+	_ Conn          = (*i2c.Dev)(nil)
+	_ Pin           = (gpio.PinIn)(nil)
+)
+
+// NewTSL2591 sets up a TSL2591 chip via the I2C protocol, sets its gain and timing
+// attributes, and returns an error if any occurred in that process or if the
+// TSL2591 was not found
+func NewTSL2591(opts *Opts) (*TSL2591, error) {
+	// Use default opts if not set
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	// Make sure periph is initialized, unless the application takes care of it
+	if !opts.SkipHostInit {
+		if _, err := host.Init(); err != nil {
+			return nil, fmt.Errorf("unable to init host: %w", err)
+		}
+	}
+
+	// Open the first available I2C bus:
+	bus, err := i2creg.Open(opts.Bus)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open I2C bus: %w", err)
+	}
+
+	// Address the device on the I2C bus:
+	addr := opts.Address
+	if addr == 0 {
+		addr = Addr
+	}
+	dev := &i2c.Dev{Addr: addr, Bus: bus}
+	tsl, err := NewTSL2591WithConn(dev, opts)
+	if err != nil {
+		_ = bus.Close()
+		return nil, err
+	}
+	tsl.bus = bus
+	tsl.opts.Address = addr
+	return tsl, nil
+}
+
+// reopenBus closes the bus and opens it again
+func (tsl *TSL2591) reopenBus() error {
+	_ = tsl.bus.Close()
+	tsl.bus = nil
+	bus, err := i2creg.Open(tsl.opts.Bus)
+	if err != nil {
+		return err
+	}
+	tsl.bus = bus
+	tsl.dev = &i2c.Dev{Addr: tsl.opts.Address, Bus: bus}
+	return nil
+}
+
+// configurePin configures a periph pin as input with pull-up and falling edge detection.
+// Other pins are expected to be configured by the caller.
+func configurePin(pin Pin) error {
+	if pin, ok := pin.(gpio.PinIn); ok {
+		return pin.In(gpio.PullUp, gpio.FallingEdge)
+	}
+	return nil
+}
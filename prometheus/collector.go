@@ -0,0 +1,127 @@
+// Package prometheus provides a Prometheus collector for the TSL2591 driver.
+//
+// Example:
+//
+//	sensor, err := tsl2591.NewTSL2591(tsl2591.DefaultOptions())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer sensor.Close()
+//	prom.MustRegister(prometheus.NewCollector(sensor))
+//	http.Handle("/metrics", promhttp.Handler())
+//	log.Fatal(http.ListenAndServe(":9101", nil))
+package prometheus
+
+import (
+	"sync"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace is the namespace of all exported metrics
+const Namespace = "tsl2591"
+
+// Collector takes a measurement on every scrape and exports it
+// together with the driver counters. If the measurement fails, the light
+// metrics are left out and tsl2591_scrape_errors_total is incremented.
+type Collector struct {
+	sensor *tsl2591.TSL2591
+
+	// mu serializes scrapes and guards scrapeErrors.
+	// Concurrent scrapes queue until the running measurement completes.
+	mu           sync.Mutex
+	scrapeErrors uint64
+
+	lux              *prom.Desc
+	visible          *prom.Desc
+	infrared         *prom.Desc
+	fullSpectrum     *prom.Desc
+	channel          *prom.Desc
+	saturated        *prom.Desc
+	scrapeErrorsDesc *prom.Desc
+	reads            *prom.Desc
+	errors           *prom.Desc
+	overflows        *prom.Desc
+	retries          *prom.Desc
+	autoRangeChanges *prom.Desc
+	recoveries       *prom.Desc
+}
+
+var _ prom.Collector = (*Collector)(nil)
+
+// NewCollector creates a new collector for the sensor
+func NewCollector(sensor *tsl2591.TSL2591) *Collector {
+	return &Collector{
+		sensor:           sensor,
+		lux:              newDesc("lux", "Calculated illuminance in lux", nil),
+		visible:          newDesc("visible", "Visible light in counts", nil),
+		infrared:         newDesc("infrared", "Infrared light in counts", nil),
+		fullSpectrum:     newDesc("full_spectrum", "Full spectrum light in counts", nil),
+		channel:          newDesc("channel_counts", "Raw ADC counts per channel", []string{"channel"}),
+		saturated:        newDesc("saturated", "Whether a channel overflowed during the last measurement", nil),
+		scrapeErrorsDesc: newDesc("scrape_errors_total", "Number of scrapes for which the measurement failed", nil),
+		reads:            newDesc("reads_total", "Number of successful channel readings", nil),
+		errors:           newDesc("bus_errors_total", "Number of failed I2C transactions", []string{"direction"}),
+		overflows:        newDesc("overflows_total", "Number of readings with a saturated channel", nil),
+		retries:          newDesc("retries_total", "Number of re-reads caused by auto ranging or the overflow policy", nil),
+		autoRangeChanges: newDesc("auto_range_changes_total", "Number of settings changes by auto ranging or the overflow policy", nil),
+		recoveries:       newDesc("recoveries_total", "Number of attempts to recover the sensor", nil),
+	}
+}
+
+func newDesc(name, help string, labels []string) *prom.Desc {
+	return prom.NewDesc(prom.BuildFQName(Namespace, "", name), help, labels, nil)
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	ch <- c.lux
+	ch <- c.visible
+	ch <- c.infrared
+	ch <- c.fullSpectrum
+	ch <- c.channel
+	ch <- c.saturated
+	ch <- c.scrapeErrorsDesc
+	ch <- c.reads
+	ch <- c.errors
+	ch <- c.overflows
+	ch <- c.retries
+	ch <- c.autoRangeChanges
+	ch <- c.recoveries
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A failed measurement only omits the light metrics, so the scrape still
+	// succeeds and the counters, including scrape_errors_total, are exported
+	m, err := c.sensor.Measure()
+	if err != nil {
+		c.scrapeErrors++
+	} else {
+		saturated := 0.0
+		if m.Saturated {
+			saturated = 1
+		}
+		ch <- prom.MustNewConstMetric(c.lux, prom.GaugeValue, m.Lux)
+		ch <- prom.MustNewConstMetric(c.visible, prom.GaugeValue, float64(m.Visible))
+		ch <- prom.MustNewConstMetric(c.infrared, prom.GaugeValue, float64(m.Infrared))
+		ch <- prom.MustNewConstMetric(c.fullSpectrum, prom.GaugeValue, float64(m.FullSpectrum))
+		ch <- prom.MustNewConstMetric(c.channel, prom.GaugeValue, float64(m.Chan0), "0")
+		ch <- prom.MustNewConstMetric(c.channel, prom.GaugeValue, float64(m.Chan1), "1")
+		ch <- prom.MustNewConstMetric(c.saturated, prom.GaugeValue, saturated)
+	}
+	ch <- prom.MustNewConstMetric(c.scrapeErrorsDesc, prom.CounterValue, float64(c.scrapeErrors))
+
+	metrics := c.sensor.Metrics()
+	ch <- prom.MustNewConstMetric(c.reads, prom.CounterValue, float64(metrics.Reads))
+	ch <- prom.MustNewConstMetric(c.errors, prom.CounterValue, float64(metrics.ReadErrors), "read")
+	ch <- prom.MustNewConstMetric(c.errors, prom.CounterValue, float64(metrics.WriteErrors), "write")
+	ch <- prom.MustNewConstMetric(c.overflows, prom.CounterValue, float64(metrics.Overflows))
+	ch <- prom.MustNewConstMetric(c.retries, prom.CounterValue, float64(metrics.Retries))
+	ch <- prom.MustNewConstMetric(c.autoRangeChanges, prom.CounterValue, float64(metrics.AutoRangeChanges))
+	ch <- prom.MustNewConstMetric(c.recoveries, prom.CounterValue, float64(metrics.Recoveries))
+}
@@ -0,0 +1,58 @@
+package tsl2591
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Recover tries to bring the sensor back after a failure like a brown-out.
+// The bus is reopened if enabled in Opts.ReopenBus, after which the device ID is
+// verified and the stored gain, timing and enable settings are re-applied.
+func (tsl *TSL2591) Recover() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.recover()
+}
+
+// recover tries to bring the sensor back after a failure
+func (tsl *TSL2591) recover() error {
+	if tsl.opts.ReopenBus && tsl.bus != nil {
+		if err := tsl.reopenBus(); err != nil {
+			return fmt.Errorf("unable to reopen I2C bus: %w", err)
+		}
+	}
+
+	if err := tsl.ping(); err != nil {
+		return err
+	}
+	return tsl.restore()
+}
+
+// handleReadFailure counts the failed reading and recovers
+// the sensor once Opts.RecoverAfter is reached.
+// The returned error always wraps the provided error.
+func (tsl *TSL2591) handleReadFailure(ctx context.Context, err error) error {
+	if ctx.Err() != nil || tsl.opts.RecoverAfter <= 0 {
+		return err
+	}
+	if errors.Is(err, ErrNotEnabled) || errors.Is(err, ErrClosed) {
+		// Recovery doesn't help if the sensor isn't in use
+		return err
+	}
+	tsl.failures++
+	tsl.opts.Logger.Warn("TSL2591: reading failed", "error", err, "failures", tsl.failures)
+	if tsl.failures < tsl.opts.RecoverAfter {
+		return err
+	}
+
+	tsl.failures = 0
+	tsl.metrics.recoveries.Add(1)
+	tsl.opts.Logger.Warn("TSL2591: recovering sensor", "reopen_bus", tsl.opts.ReopenBus && tsl.bus != nil)
+	if recoverErr := tsl.recover(); recoverErr != nil {
+		tsl.opts.Logger.Error("TSL2591: recovery failed", "error", recoverErr)
+		return fmt.Errorf("%w (recovery failed: %v)", err, recoverErr)
+	}
+	tsl.opts.Logger.Info("TSL2591: sensor recovered")
+	return err
+}
@@ -0,0 +1,121 @@
+package tsl2591
+
+import "fmt"
+
+// maxRegister is the highest address which can be encoded in a command
+const maxRegister byte = 0b00011111
+
+// ReadRegister reads a single register at the provided address (0x00-0x1f).
+//
+// This is an advanced escape hatch to access chip features which are not
+// covered by the driver. Reading the status or channel registers might
+// interfere with the driver, e.g. by latching the channel data.
+func (tsl *TSL2591) ReadRegister(address byte) (byte, error) {
+	if address > maxRegister {
+		return 0, fmt.Errorf("%w: 0x%02x", ErrInvalidRegister, address)
+	}
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	value, err := tsl.readU8(address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read register 0x%02x: %w", address, err)
+	}
+	return value, nil
+}
+
+// WriteRegister writes a single register at the provided address (0x00-0x1f).
+//
+// This is an advanced escape hatch to access chip features which are not
+// covered by the driver. It is unsafe: the driver doesn't validate the value
+// and writing the wrong register might disable or reset the sensor.
+// Writes to the enable and control registers update the driver state,
+// so later calls like SetGain don't revert them.
+// Reserved integration times are rejected with ErrInvalidTiming.
+func (tsl *TSL2591) WriteRegister(address, value byte) error {
+	if address > maxRegister {
+		return fmt.Errorf("%w: 0x%02x", ErrInvalidRegister, address)
+	}
+	if timing := IntegrationTime(value & 0b00000111); address == RegisterControl && !timing.valid() {
+		// The driver can't calculate lux for reserved integration times
+		return fmt.Errorf("%w: 0x%02x", ErrInvalidTiming, byte(timing))
+	}
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	if err := tsl.writeU8(address, value); err != nil && !(address == RegisterControl && value&ControlSRESET != 0) {
+		// The device resets before acknowledging the write, so the error is ignored on reset
+		return fmt.Errorf("failed to write register 0x%02x: %w", address, err)
+	}
+
+	switch address {
+	case RegisterEnable:
+		tsl.enabled = value&(EnablePowerOn|EnableAEN) == EnablePowerOn|EnableAEN
+		tsl.enableOpts = EnableOptions{
+			ALSInterrupt:        value&EnableAIEN != 0,
+			NoPersistInterrupt:  value&EnableNPIEN != 0,
+			SleepAfterInterrupt: value&EnableSAI != 0,
+		}
+	case RegisterControl:
+		if value&ControlSRESET != 0 {
+			// All registers are back at their power-on defaults
+			tsl.enabled, tsl.enableOpts = false, EnableOptions{}
+			value = 0
+		}
+		tsl.control = value
+		tsl.gain = Gain(value & 0b00110000)
+		tsl.timing = IntegrationTime(value & 0b00000111)
+		tsl.stale = true
+	}
+	return nil
+}
+
+// DocumentedRegisters lists the addresses of all registers documented in the datasheet
+var DocumentedRegisters = []byte{
+	RegisterEnable,
+	RegisterControl,
+	RegisterThresholdAILTL,
+	RegisterThresholdAILTH,
+	RegisterThresholdAIHTL,
+	RegisterThresholdAIHTH,
+	RegisterThresholdNPAILTL,
+	RegisterThresholdNPAILTH,
+	RegisterThresholdNPAIHTL,
+	RegisterThresholdNPAIHTH,
+	RegisterPersistFilter,
+	RegisterPackagePID,
+	RegisterDeviceID,
+	RegisterDeviceStatus,
+	RegisterChan0Low,
+	RegisterChan0High,
+	RegisterChan1Low,
+	RegisterChan1High,
+}
+
+// RegisterDump returns the current values of all DocumentedRegisters by address
+func (tsl *TSL2591) RegisterDump() (map[byte]byte, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	regs := make(map[byte]byte, len(DocumentedRegisters))
+	for _, address := range DocumentedRegisters {
+		value, err := tsl.readU8(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read register 0x%02x: %w", address, err)
+		}
+		regs[address] = value
+	}
+	return regs, nil
+}
+
+// DumpRegisters returns the current values of all DocumentedRegisters by address.
+// Unlike RegisterDump, it reads directly from the connection, which allows to
+// inspect a sensor without the setup done by NewTSL2591WithConn.
+func DumpRegisters(dev Conn) (map[byte]byte, error) {
+	regs := make(map[byte]byte, len(DocumentedRegisters))
+	value := make([]byte, 1)
+	for _, address := range DocumentedRegisters {
+		if err := dev.Tx([]byte{CommandBit | address}, value); err != nil {
+			return nil, fmt.Errorf("failed to read register 0x%02x: %w", address, err)
+		}
+		regs[address] = value[0]
+	}
+	return regs, nil
+}
@@ -0,0 +1,69 @@
+package tsl2591
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// resetTimeout is the maximum time to wait for the device to come back after a reset
+const resetTimeout = 100 * time.Millisecond
+
+// Reset performs a system reset of the TSL2591, which is useful to recover
+// a wedged sensor without a power cycle. After the device is back,
+// the stored gain, timing, sleep after interrupt and enable settings are re-applied.
+func (tsl *TSL2591) Reset() error {
+	return tsl.ResetContext(context.Background())
+}
+
+// ResetContext is Reset which honors cancellation and deadlines of the context
+func (tsl *TSL2591) ResetContext(ctx context.Context) error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.reset(ctx)
+}
+
+// reset performs a system reset and re-applies the stored settings
+func (tsl *TSL2591) reset(ctx context.Context) error {
+	// The device resets before acknowledging the write, so the
+	// resulting error is expected and ignored.
+	_ = tsl.writeU8(RegisterControl, ControlSRESET)
+
+	// Wait for the device to come back
+	deadline := time.Now().Add(resetTimeout)
+	for {
+		deviceID, err := tsl.readU8(RegisterDeviceID)
+		if err == nil && deviceID == DeviceID {
+			break
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("%w: device did not come back after reset: %w", ErrNotReady, err)
+			}
+			return fmt.Errorf("%w: %w", ErrNotReady, UnexpectedDeviceIDError{Actual: deviceID, Expected: DeviceID})
+		}
+		if err := sleepContext(ctx, dataPollInterval); err != nil {
+			return err
+		}
+	}
+
+	return tsl.restore()
+}
+
+// restore re-applies the stored gain, timing and enable settings
+func (tsl *TSL2591) restore() error {
+	// The sensor lost its state, so the first read must wait for AVALID again
+	tsl.lastRead = time.Time{}
+	if err := tsl.setGain(tsl.gain); err != nil {
+		return fmt.Errorf("unable to restore gain: %w", err)
+	}
+	if err := tsl.setTiming(tsl.timing); err != nil {
+		return fmt.Errorf("unable to restore timing: %w", err)
+	}
+	if tsl.enabled {
+		if err := tsl.enable(); err != nil {
+			return fmt.Errorf("unable to enable sensor: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package tsl2591
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// WindowStats holds the statistics of the values within a time window
+type WindowStats struct {
+	Min    float64
+	Max    float64
+	Mean   float64
+	StdDev float64
+	Count  int
+}
+
+// RollingStats maintains the statistics of values over a sliding time window.
+// It is safe for concurrent use.
+type RollingStats struct {
+	mu      sync.Mutex
+	window  time.Duration
+	samples []timedValue
+}
+
+// timedValue is a value with the time it was recorded
+type timedValue struct {
+	time  time.Time
+	value float64
+}
+
+// NewRollingStats returns rolling statistics over the provided time window
+func NewRollingStats(window time.Duration) *RollingStats {
+	return &RollingStats{window: window}
+}
+
+// Add records a value at the provided time
+func (r *RollingStats) Add(t time.Time, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples = append(r.samples, timedValue{time: t, value: value})
+	r.prune(t)
+}
+
+// Snapshot returns the statistics of the values recorded within the window up to now
+func (r *RollingStats) Snapshot() WindowStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(time.Now())
+
+	stats := WindowStats{Count: len(r.samples)}
+	if len(r.samples) == 0 {
+		return stats
+	}
+	values := make([]float64, len(r.samples))
+	stats.Min, stats.Max = math.Inf(1), math.Inf(-1)
+	for i, s := range r.samples {
+		values[i] = s.value
+		stats.Min = math.Min(stats.Min, s.value)
+		stats.Max = math.Max(stats.Max, s.value)
+	}
+	avg := average(values)
+	stats.Mean, stats.StdDev = avg.Mean, avg.StdDev
+	return stats
+}
+
+// prune drops the samples which are older than the window relative to now
+func (r *RollingStats) prune(now time.Time) {
+	cutoff := now.Add(-r.window)
+	i := 0
+	for i < len(r.samples) && r.samples[i].time.Before(cutoff) {
+		i++
+	}
+	r.samples = r.samples[i:]
+}
+
+// Stats returns the rolling statistics of the lux values measured within Opts.StatsWindow.
+// Returns the zero value if Opts.StatsWindow is not set.
+func (tsl *TSL2591) Stats() WindowStats {
+	if tsl.stats == nil {
+		return WindowStats{}
+	}
+	return tsl.stats.Snapshot()
+}
@@ -0,0 +1,51 @@
+package tsl2591
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRollingStats(t *testing.T) {
+	type sample struct {
+		age   time.Duration
+		value float64
+	}
+	tests := []struct {
+		name     string
+		samples  []sample
+		expected WindowStats
+	}{
+		{name: "empty", expected: WindowStats{}},
+		{
+			name:     "single",
+			samples:  []sample{{age: time.Second, value: 7}},
+			expected: WindowStats{Min: 7, Max: 7, Mean: 7, Count: 1},
+		},
+		{
+			name:     "within window",
+			samples:  []sample{{age: 30 * time.Second, value: 1}, {age: 20 * time.Second, value: 3}, {age: 10 * time.Second, value: 5}},
+			expected: WindowStats{Min: 1, Max: 5, Mean: 3, StdDev: 2, Count: 3},
+		},
+		{
+			name:     "expired samples are dropped",
+			samples:  []sample{{age: 2 * time.Minute, value: 100}, {age: 90 * time.Second, value: 50}, {age: time.Second, value: 4}},
+			expected: WindowStats{Min: 4, Max: 4, Mean: 4, Count: 1},
+		},
+	}
+	for _, tt := range tests {
+		stats := NewRollingStats(time.Minute)
+		now := time.Now()
+		for _, s := range tt.samples {
+			stats.Add(now.Add(-s.age), s.value)
+		}
+		snapshot := stats.Snapshot()
+		if snapshot.Count != tt.expected.Count ||
+			snapshot.Min != tt.expected.Min ||
+			snapshot.Max != tt.expected.Max ||
+			math.Abs(snapshot.Mean-tt.expected.Mean) > 1e-9 ||
+			math.Abs(snapshot.StdDev-tt.expected.StdDev) > 1e-9 {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.expected, snapshot)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package tsl2591
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Sampler takes measurements which are guaranteed to come from a new conversion.
+// Naively polling faster than the integration time returns the same data repeatedly,
+// so Next waits until a full ALS cycle passed since the previous measurement
+// and retries measurements which aren't Fresh.
+// It is safe for concurrent use.
+type Sampler struct {
+	tsl *TSL2591
+
+	mu   sync.Mutex
+	last Measurement
+}
+
+// NewSampler returns a sampler for the sensor
+func NewSampler(tsl *TSL2591) *Sampler {
+	return &Sampler{tsl: tsl}
+}
+
+// Next waits until a new conversion is available and returns it
+func (s *Sampler) Next(ctx context.Context) (Measurement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.last.Timestamp.IsZero() {
+		ready := s.last.Timestamp.Add(cycleDuration(s.last.Timing))
+		if err := sleepContext(ctx, time.Until(ready)); err != nil {
+			return Measurement{}, err
+		}
+	}
+
+	for {
+		m, err := s.tsl.MeasureContext(ctx)
+		if err != nil {
+			return Measurement{}, err
+		}
+		if m.Fresh {
+			s.last = m
+			return m, nil
+		}
+
+		// The sensor was read by someone else in the meantime
+		if err = sleepContext(ctx, cycleDuration(m.Timing)); err != nil {
+			return Measurement{}, err
+		}
+	}
+}
+
+// cycleDuration returns the duration of a full ALS cycle, including a margin for clock tolerance
+func cycleDuration(timing IntegrationTime) time.Duration {
+	d := timing.Duration()
+	return d + d/10
+}
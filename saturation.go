@@ -0,0 +1,62 @@
+package tsl2591
+
+import "context"
+
+// IsSaturated reads both channels and returns whether any of them
+// reached the maximum count for the current gain and integration time.
+// Auto ranging isn't applied, so saturation is reported before the gain is lowered.
+// Lux returns ErrOverflow for saturated readings.
+func (tsl *TSL2591) IsSaturated() (bool, error) {
+	c0, c1, err := tsl.saturation()
+	return c0 || c1, err
+}
+
+// IsFullSpectrumSaturated reads both channels and returns whether
+// channel 0 (IR + visible) reached the maximum count
+func (tsl *TSL2591) IsFullSpectrumSaturated() (bool, error) {
+	c0, _, err := tsl.saturation()
+	return c0, err
+}
+
+// IsInfraredSaturated reads both channels and returns whether
+// channel 1 (IR only) reached the maximum count
+func (tsl *TSL2591) IsInfraredSaturated() (bool, error) {
+	_, c1, err := tsl.saturation()
+	return c1, err
+}
+
+// saturation reads both channels and returns per channel whether it is saturated
+func (tsl *TSL2591) saturation() (bool, bool, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	c0, c1, err := tsl.readChannels(context.Background())
+	if err != nil {
+		return false, false, err
+	}
+	maxCounts := tsl.maxCounts()
+	return c0 >= maxCounts, c1 >= maxCounts, nil
+}
+
+// Headroom reads both channels and returns the percentage of full scale remaining
+// on the dominant channel for the current gain and integration time.
+// Auto ranging isn't applied.
+// Returns 0 when saturated.
+func (tsl *TSL2591) Headroom() (float64, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	c0, c1, err := tsl.readChannels(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	dominant := c0
+	if c1 > c0 {
+		dominant = c1
+	}
+	maxCounts := tsl.maxCounts()
+	if dominant >= maxCounts {
+		return 0, nil
+	}
+	return 100 * (1 - float64(dominant)/float64(maxCounts)), nil
+}
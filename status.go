@@ -0,0 +1,107 @@
+package tsl2591
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DeviceStatus holds the decoded status register of the TSL2591
+type DeviceStatus struct {
+	// ALSValid is set when the ALS channels have completed an integration cycle
+	ALSValid bool
+
+	// ALSInterrupt is set when the device is asserting an ALS interrupt
+	ALSInterrupt bool
+
+	// NoPersistInterrupt is set when the device is asserting a no-persist interrupt
+	NoPersistInterrupt bool
+}
+
+// Status reads and decodes the status register
+func (tsl *TSL2591) Status() (DeviceStatus, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.status()
+}
+
+// status reads and decodes the status register
+func (tsl *TSL2591) status() (DeviceStatus, error) {
+	status, err := tsl.readU8(RegisterDeviceStatus)
+	if err != nil {
+		return DeviceStatus{}, fmt.Errorf("failed to read device status: %w", err)
+	}
+	return DeviceStatus{
+		ALSValid:           status&StatusAVALID != 0,
+		ALSInterrupt:       status&StatusAINT != 0,
+		NoPersistInterrupt: status&StatusNPINTR != 0,
+	}, nil
+}
+
+// dataPollInterval is the interval at which the status register is polled while waiting for data
+const dataPollInterval = 10 * time.Millisecond
+
+// WaitForData blocks until the ALS channels hold a valid conversion.
+// If gain or timing changed since the last conversion, the ALS cycle is restarted first,
+// so the next reading is guaranteed to use the new settings.
+// Returns ErrDataTimeout, which matches ErrNotReady, if no valid data is available within
+// twice the integration time, or ErrNotEnabled if the sensor is disabled.
+func (tsl *TSL2591) WaitForData(ctx context.Context) error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.waitForData(ctx)
+}
+
+// waitForData blocks until the ALS channels hold a valid conversion
+func (tsl *TSL2591) waitForData(ctx context.Context) error {
+	if !tsl.enabled {
+		return ErrNotEnabled
+	}
+	if tsl.stale {
+		if err := tsl.restartALS(); err != nil {
+			return err
+		}
+		tsl.stale = false
+	}
+
+	timeout := time.NewTimer(2 * tsl.timing.Duration())
+	defer timeout.Stop()
+	ticker := time.NewTicker(dataPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := tsl.status()
+		if err != nil {
+			return err
+		}
+		if status.ALSValid {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return ErrDataTimeout
+		case <-ticker.C:
+		}
+	}
+}
+
+// restartALS toggles the ALS enable bit, which clears AVALID and starts a new integration cycle
+func (tsl *TSL2591) restartALS() error {
+	enable, err := tsl.readU8(RegisterEnable)
+	if err != nil {
+		return fmt.Errorf("failed to read enable register: %w", err)
+	}
+	if enable&EnableAEN == 0 {
+		return nil
+	}
+	if err = tsl.writeU8(RegisterEnable, enable&^EnableAEN); err != nil {
+		return fmt.Errorf("failed to disable ALS: %w", err)
+	}
+	if err = tsl.writeU8(RegisterEnable, enable); err != nil {
+		return fmt.Errorf("failed to re-enable ALS: %w", err)
+	}
+	tsl.lastRead = time.Time{}
+	return nil
+}
@@ -0,0 +1,29 @@
+package tsl2591
+
+import (
+	"testing"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+func TestStatus(t *testing.T) {
+	tests := map[byte]DeviceStatus{
+		0x00:                      {},
+		StatusAVALID:              {ALSValid: true},
+		StatusAVALID | StatusAINT: {ALSValid: true, ALSInterrupt: true},
+		StatusNPINTR | 0x0e:       {NoPersistInterrupt: true},
+		StatusAINT | StatusNPINTR: {ALSInterrupt: true, NoPersistInterrupt: true},
+	}
+	for register, expected := range tests {
+		tsl := newTestSensor(t, nil,
+			i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterDeviceStatus}, R: []byte{register}},
+		)
+		status, err := tsl.Status()
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+		if status != expected {
+			t.Errorf("status 0x%02x: expected %+v, got %+v", register, expected, status)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package tsl2591
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// stuckDetector counts consecutive readings with bit-identical raw counts.
+// A hung ALS keeps returning the counts of its last conversion, while even
+// constant light produces some noise in the counts of a working sensor.
+type stuckDetector struct {
+	c0, c1 uint16
+
+	// at is the time of the last counted reading
+	at time.Time
+
+	// count is the number of consecutive readings repeating the counts
+	count int
+}
+
+// checkStuck records the raw counts and returns ErrStuck once the sensor is considered stuck.
+// If Opts.ResetWhenStuck is set, the sensor is reset as well.
+func (tsl *TSL2591) checkStuck(ctx context.Context, c0, c1 uint16) error {
+	if tsl.opts.StuckAfter <= 0 {
+		return nil
+	}
+
+	// Dark and saturated readings are legitimately identical
+	now := time.Now()
+	if (c0 == 0 && c1 == 0) || c0 >= tsl.maxCounts() || c1 >= tsl.maxCounts() {
+		tsl.stuck = stuckDetector{}
+		return nil
+	}
+	if c0 != tsl.stuck.c0 || c1 != tsl.stuck.c1 {
+		tsl.stuck = stuckDetector{c0: c0, c1: c1, at: now}
+		return nil
+	}
+
+	// Reads within the same integration cycle return the same conversion
+	if now.Sub(tsl.stuck.at) < cycleDuration(tsl.timing) {
+		return nil
+	}
+	tsl.stuck.at = now
+	tsl.stuck.count++
+	if tsl.stuck.count < tsl.opts.StuckAfter {
+		return nil
+	}
+
+	tsl.opts.Logger.Warn("TSL2591: sensor stuck", "chan0", c0, "chan1", c1, "repeats", tsl.stuck.count)
+	tsl.stuck = stuckDetector{}
+	if !tsl.opts.ResetWhenStuck {
+		return fmt.Errorf("%w: raw counts %d and %d unchanged", ErrStuck, c0, c1)
+	}
+	if err := tsl.reset(ctx); err != nil {
+		return fmt.Errorf("%w: reset failed: %w", ErrStuck, err)
+	}
+	tsl.opts.Logger.Info("TSL2591: stuck sensor reset")
+	return fmt.Errorf("%w: sensor was reset", ErrStuck)
+}
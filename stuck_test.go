@@ -0,0 +1,87 @@
+package tsl2591
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+// measureAged ages the stuck detector by a full ALS cycle and measures,
+// so the reading counts towards detecting a stuck sensor
+func measureAged(tsl *TSL2591) error {
+	tsl.stuck.at = tsl.stuck.at.Add(-time.Second)
+	_, err := tsl.Measure()
+	return err
+}
+
+func TestStuckDetection(t *testing.T) {
+	ops := readOps(1000, 100, true)
+	for i := 0; i < 3; i++ {
+		ops = append(ops, readOps(1000, 100, false)...)
+	}
+	opts := DefaultOptions()
+	opts.StuckAfter = 2
+	tsl := newTestSensor(t, opts, ops...)
+
+	if _, err := tsl.Measure(); err != nil {
+		t.Fatalf("first reading failed: %v", err)
+	}
+
+	// Reads within the same ALS cycle legitimately return the same conversion
+	if _, err := tsl.Measure(); err != nil {
+		t.Fatalf("reading within cycle failed: %v", err)
+	}
+
+	if err := measureAged(tsl); err != nil {
+		t.Fatalf("first repeat failed: %v", err)
+	}
+	if err := measureAged(tsl); !errors.Is(err, ErrStuck) {
+		t.Fatalf("expected ErrStuck on second repeat, got %v", err)
+	}
+}
+
+func TestStuckDetectionIgnoresDarkAndChanges(t *testing.T) {
+	ops := readOps(0, 0, true)
+	ops = append(ops, readOps(0, 0, false)...)
+	ops = append(ops, readOps(0, 0, false)...)
+	ops = append(ops, readOps(1000, 100, false)...)
+	ops = append(ops, readOps(1001, 100, false)...)
+	ops = append(ops, readOps(1001, 100, false)...)
+	opts := DefaultOptions()
+	opts.StuckAfter = 1
+	tsl := newTestSensor(t, opts, ops...)
+
+	for i := 0; i < 5; i++ {
+		if err := measureAged(tsl); err != nil {
+			t.Fatalf("reading %d failed: %v", i, err)
+		}
+	}
+	if err := measureAged(tsl); !errors.Is(err, ErrStuck) {
+		t.Fatalf("expected ErrStuck, got %v", err)
+	}
+}
+
+func TestStuckDetectionResets(t *testing.T) {
+	ops := readOps(1000, 100, true)
+	ops = append(ops, readOps(1000, 100, false)...)
+	ops = append(ops,
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, ControlSRESET}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterDeviceID}, R: []byte{DeviceID}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainMed)}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainMed)}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterEnable, EnablePowerOn | EnableAEN}},
+	)
+	opts := DefaultOptions()
+	opts.StuckAfter = 1
+	opts.ResetWhenStuck = true
+	tsl := newTestSensor(t, opts, ops...)
+
+	if err := measureAged(tsl); err != nil {
+		t.Fatalf("first reading failed: %v", err)
+	}
+	if err := measureAged(tsl); !errors.Is(err, ErrStuck) {
+		t.Fatalf("expected ErrStuck, got %v", err)
+	}
+}
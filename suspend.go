@@ -0,0 +1,39 @@
+package tsl2591
+
+import (
+	"context"
+	"fmt"
+)
+
+// Suspend powers down the sensor between measurements, like Disable. The configuration
+// of the driver, including the enable options, is kept for Resume.
+// Readings fail with ErrNotEnabled until Resume is called.
+func (tsl *TSL2591) Suspend() error {
+	return tsl.Disable()
+}
+
+// Resume powers up the sensor after Suspend. The gain, timing and enable options
+// are re-applied, in case the sensor lost power, after which Resume blocks
+// until the first valid conversion is available.
+func (tsl *TSL2591) Resume() error {
+	return tsl.ResumeContext(context.Background())
+}
+
+// ResumeContext is Resume which honors cancellation and deadlines of the context
+func (tsl *TSL2591) ResumeContext(ctx context.Context) error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	if err := tsl.setGain(tsl.gain); err != nil {
+		return fmt.Errorf("unable to restore gain: %w", err)
+	}
+	if err := tsl.setTiming(tsl.timing); err != nil {
+		return fmt.Errorf("unable to restore timing: %w", err)
+	}
+	if err := tsl.enable(); err != nil {
+		return fmt.Errorf("unable to resume sensor: %w", err)
+	}
+
+	// Powering on starts a new ALS cycle with the current settings
+	tsl.stale = false
+	return tsl.waitForData(ctx)
+}
@@ -0,0 +1,15 @@
+//go:build tinygo
+
+package tsl2591
+
+import "errors"
+
+// reopenBus is not supported on TinyGo, as the bus is always provided by the caller
+func (tsl *TSL2591) reopenBus() error {
+	return errors.New("reopening the bus is not supported on TinyGo")
+}
+
+// configurePin is a no-op on TinyGo, the pin must be configured by the caller
+func configurePin(Pin) error {
+	return nil
+}
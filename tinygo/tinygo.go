@@ -0,0 +1,43 @@
+// Package tinygo connects the TSL2591 driver to the I2C bus of TinyGo's machine package,
+// so the driver can be used on microcontrollers.
+//
+// Example:
+//
+//	machine.I2C0.Configure(machine.I2CConfig{})
+//	sensor, err := tinygo.New(machine.I2C0, tsl2591.DefaultOptions())
+package tinygo
+
+import (
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// Bus is an I2C bus like *machine.I2C or drivers.I2C
+type Bus interface {
+	Tx(addr uint16, w, r []byte) error
+}
+
+// Dev is a device on a TinyGo I2C bus. It implements tsl2591.Conn.
+type Dev struct {
+	Bus  Bus
+	Addr uint16
+}
+
+var _ tsl2591.Conn = (*Dev)(nil)
+
+// Tx implements tsl2591.Conn
+func (d *Dev) Tx(w, r []byte) error {
+	return d.Bus.Tx(d.Addr, w, r)
+}
+
+// New sets up a TSL2591 chip on the provided bus at Opts.Address.
+// Opts.Bus and Opts.ReopenBus are ignored.
+func New(bus Bus, opts *tsl2591.Opts) (*tsl2591.TSL2591, error) {
+	if opts == nil {
+		opts = tsl2591.DefaultOptions()
+	}
+	addr := opts.Address
+	if addr == 0 {
+		addr = tsl2591.Addr
+	}
+	return tsl2591.NewTSL2591WithConn(&Dev{Bus: bus, Addr: addr}, opts)
+}
@@ -6,61 +6,279 @@
 package tsl2591
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
-	"math"
-
-	"periph.io/x/conn/v3/i2c"
-	"periph.io/x/conn/v3/i2c/i2creg"
-	"periph.io/x/host/v3"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
 )
 
 // Opts holds various configuration options for the sensor
 type Opts struct {
 	// Bus name, alias or its number.
 	// See https://pkg.go.dev/periph.io/x/conn/v3/i2c/i2creg#Open for more info.
-	Bus    string
+	Bus string
+
+	// I2C address of the sensor. Defaults to Addr if zero.
+	Address uint16
+
+	// SkipHostInit skips initializing periph in NewTSL2591, e.g. if the application
+	// already called host.Init or registered a custom I2C bus driver.
+	SkipHostInit bool
+
 	Gain   Gain
 	Timing IntegrationTime
+
+	// SleepAfterInterrupt puts the sensor to sleep after an ALS interrupt
+	// until the interrupt is cleared. See SetSleepAfterInterrupt.
+	SleepAfterInterrupt bool
+
+	// AutoGain automatically steps the gain up or down based on the raw counts
+	// of each reading. See SetAutoGain.
+	AutoGain bool
+
+	// AutoRange automatically manages both gain and integration time.
+	// Takes precedence over AutoGain. See SetAutoRange.
+	AutoRange *AutoRange
+
+	// RecoverAfter is the number of consecutive failed readings after which
+	// the sensor is recovered automatically. Zero disables automatic recovery.
+	// See Recover.
+	RecoverAfter int
+
+	// ReopenBus closes and reopens the I2C bus during recovery.
+	// Only applies if the bus was opened by NewTSL2591.
+	ReopenBus bool
+
+	// StuckAfter is the number of consecutive readings repeating the raw counts of the
+	// previous reading bit-identically, each taken at least a full ALS cycle apart,
+	// after which the sensor is considered stuck and the reading fails with ErrStuck.
+	// Dark and saturated readings are ignored. Zero disables the detection.
+	StuckAfter int
+
+	// ResetWhenStuck resets the sensor once it is considered stuck. See Reset.
+	ResetWhenStuck bool
+
+	// LuxCoefficients overrides the coefficients of the lux calculation.
+	// Defaults to DefaultLuxCoefficients if zero.
+	LuxCoefficients LuxCoefficients
+
+	// LuxMethod selects the equation used to calculate lux.
+	// Defaults to LuxMethodCircuitPython.
+	LuxMethod LuxMethod
+
+	// SkyBrightnessOffset is the zero point calibration in magnitudes
+	// added by SkyBrightness
+	SkyBrightnessOffset float64
+
+	// OverflowPolicy defines how Lux and Measure handle saturated channels.
+	// Defaults to OverflowReturnError.
+	OverflowPolicy OverflowPolicy
+
+	// SmoothingAlpha enables an exponential moving average on the lux values
+	// returned by Lux and Measure if between 0 and 1. Zero disables smoothing.
+	// See EMA.
+	SmoothingAlpha float64
+
+	// OutlierRejection selects how AverageLux and AverageRawLuminosity reject outliers.
+	// Defaults to OutlierRejectionNone.
+	OutlierRejection OutlierRejection
+
+	// OutlierThreshold is the threshold of the outlier rejection. Defaults to
+	// DefaultMADThreshold or DefaultSigmaThreshold depending on the method if zero.
+	OutlierThreshold float64
+
+	// StatsWindow enables rolling statistics of the lux values over the provided
+	// time window if non-zero. See Stats.
+	StatsWindow time.Duration
+
+	// InterruptPin is the GPIO connected to the INT pin of the sensor.
+	// If set, WaitForLuxAbove and WaitForLuxBelow wait for interrupts instead of polling.
+	// Periph pins are configured automatically. Other pins, e.g. on TinyGo,
+	// must be configured as input with falling edge detection by the caller.
+	InterruptPin Pin
+
+	// Logger receives warnings like failed readings and recovery events,
+	// as well as automatic changes of the settings. Logging is disabled if nil.
+	Logger *slog.Logger
+	// Trace logs every I2C transaction with its command, data, duration
+	// and error at debug level through Logger
+	Trace bool
 }
 
 func DefaultOptions() *Opts {
 	return &Opts{
-		Bus:    "",
-		Gain:   GainMed,
-		Timing: IntegrationTime100MS,
+		Bus:     "",
+		Address: Addr,
+		Gain:    GainMed,
+		Timing:  IntegrationTime100MS,
+
+		LuxCoefficients: DefaultLuxCoefficients(),
+	}
+}
+
+// validate checks the options for values which can't be applied.
+// Returned errors match ErrInvalidConfig.
+func (o *Opts) validate() error {
+	if !o.Gain.valid() {
+		return fmt.Errorf("%w: %w: 0x%02x", ErrInvalidConfig, ErrInvalidGain, byte(o.Gain))
+	}
+	if !o.Timing.valid() {
+		return fmt.Errorf("%w: %w: 0x%02x", ErrInvalidConfig, ErrInvalidTiming, byte(o.Timing))
 	}
+	if o.LuxMethod > LuxMethodAMS {
+		return fmt.Errorf("%w: unknown lux method %d", ErrInvalidConfig, o.LuxMethod)
+	}
+	if o.OverflowPolicy > OverflowAutoReduceGainAndRetry {
+		return fmt.Errorf("%w: unknown overflow policy %d", ErrInvalidConfig, o.OverflowPolicy)
+	}
+	if o.OutlierRejection > OutlierRejectionSigmaClip {
+		return fmt.Errorf("%w: unknown outlier rejection %d", ErrInvalidConfig, o.OutlierRejection)
+	}
+	if o.OutlierThreshold < 0 {
+		return fmt.Errorf("%w: negative outlier threshold %g", ErrInvalidConfig, o.OutlierThreshold)
+	}
+	if o.SmoothingAlpha < 0 || o.SmoothingAlpha > 1 {
+		return fmt.Errorf("%w: smoothing alpha %g not between 0 and 1", ErrInvalidConfig, o.SmoothingAlpha)
+	}
+	if o.RecoverAfter < 0 {
+		return fmt.Errorf("%w: negative recover after %d", ErrInvalidConfig, o.RecoverAfter)
+	}
+	if o.StuckAfter < 0 {
+		return fmt.Errorf("%w: negative stuck after %d", ErrInvalidConfig, o.StuckAfter)
+	}
+	if o.StatsWindow < 0 {
+		return fmt.Errorf("%w: negative stats window %s", ErrInvalidConfig, o.StatsWindow)
+	}
+	if o.AutoRange != nil {
+		low, high := o.AutoRange.Low, o.AutoRange.High
+		if low == 0 {
+			low = DefaultAutoRangeLow
+		}
+		if high == 0 {
+			high = DefaultAutoRangeHigh
+		}
+		if low < 0 || high > 1 || low >= high {
+			return fmt.Errorf("%w: auto range band %g-%g not within 0-1", ErrInvalidConfig, low, high)
+		}
+	}
+	return nil
 }
 
-// TSL2591 holds board setup detail
+// LightSensor is the common interface of lux sensors, which is satisfied by TSL2591.
+// Write application code against this interface to swap in mocks or other sensors.
+type LightSensor interface {
+	Enable() error
+	Disable() error
+	Lux() (float64, error)
+	Visible() (uint32, error)
+	Infrared() (uint16, error)
+	FullSpectrum() (uint32, error)
+}
+
+var _ LightSensor = (*TSL2591)(nil)
+
+// TSL2591 holds board setup detail.
+// It is safe for concurrent use.
 type TSL2591 struct {
-	dev    i2c.Dev
+	// mu guards the bus and the fields below
+	mu     sync.Mutex
+	dev    Conn
+	bus    io.Closer // Only set if the bus was opened by NewTSL2591
+	opts   Opts
 	gain   Gain
 	timing IntegrationTime
+
+	// enableOpts are the functions enabled by enable
+	enableOpts EnableOptions
+
+	// control is a shadow of the control register, which avoids
+	// a read-modify-write cycle on each update
+	control byte
+
+	// enabled is set when the chip was enabled through the driver
+	enabled bool
+
+	// closed is set by Close, after which all bus access fails with ErrClosed
+	closed bool
+
+	// failures is the number of consecutive failed readings
+	failures int
+
+	// stuck tracks identical raw counts to detect a stuck sensor
+	stuck stuckDetector
+
+	// darkOffset is subtracted from all readings
+	darkOffset DarkOffset
+
+	// luxCalibration is applied to all lux values
+	luxCalibration LuxCalibration
+
+	// metrics holds the driver counters
+	metrics metrics
+
+	// smoothing is applied to the lux values of Lux and Measure if set
+	smoothing *EMA
+
+	// stats records the lux values of Lux and Measure if set
+	stats *RollingStats
+
+	// autoRange enables automatic ranging on each reading if set
+	autoRange *AutoRange
+
+	// last is the most recent successful measurement
+	last Measurement
+
+	// stale is set when gain or timing changed since the ALS cycle was last restarted
+	stale bool
+
+	// lastRead is the time at which the channels were last read with the read counts.
+	// It is reset when a new ALS cycle is started, after which AVALID decides on freshness.
+	lastRead       time.Time
+	lastC0, lastC1 uint16
+
+	// fresh is set when the last read channels hold a new conversion
+	fresh bool
+
+	// haltCtx is cancelled by Halt to stop all continuous sensing
+	haltCtx    context.Context
+	haltCancel context.CancelFunc
+
+	// sensing tracks the running continuous sensing goroutines
+	sensing sync.WaitGroup
 }
 
-// NewTSL2591 sets up a TSL2591 chip via the I2C protocol, sets its gain and timing
-// attributes, and returns an error if any occurred in that process or if the
-// TSL2591 was not found
-func NewTSL2591(opts *Opts) (*TSL2591, error) {
+// NewTSL2591WithConn sets up a TSL2591 chip on an existing connection, e.g. an *i2c.Dev.
+// Bus and Address in opts are ignored. This allows to inject a scripted connection
+// like periph's i2ctest.Playback in tests, or to use the driver on TinyGo.
+func NewTSL2591WithConn(dev Conn, opts *Opts) (*TSL2591, error) {
 	// Use default opts if not set
 	if opts == nil {
 		opts = DefaultOptions()
 	}
-
-	// Make sure periph is initialized.
-	if _, err := host.Init(); err != nil {
-		return nil, fmt.Errorf("unable to init host: %w", err)
+	if err := opts.validate(); err != nil {
+		return nil, err
 	}
-
-	// Open the first available I2C bus:
-	bus, err := i2creg.Open(opts.Bus)
-	if err != nil {
-		return nil, fmt.Errorf("unable to open I2C bus: %w", err)
+	tsl := &TSL2591{dev: dev, opts: *opts}
+	tsl.enableOpts.SleepAfterInterrupt = opts.SleepAfterInterrupt
+	if tsl.opts.Logger == nil {
+		tsl.opts.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if opts.StatsWindow > 0 {
+		tsl.stats = NewRollingStats(opts.StatsWindow)
+	}
+	if opts.SmoothingAlpha > 0 {
+		tsl.smoothing = NewEMA(opts.SmoothingAlpha)
+	}
+	if tsl.opts.LuxCoefficients == (LuxCoefficients{}) {
+		tsl.opts.LuxCoefficients = DefaultLuxCoefficients()
+	}
+	tsl.SetAutoGain(opts.AutoGain)
+	if opts.AutoRange != nil {
+		tsl.SetAutoRange(opts.AutoRange)
 	}
-
-	// Address the device with address TSL2591_ADDR on the I2C bus:
-	dev := i2c.Dev{Addr: Addr, Bus: bus}
-	tsl := &TSL2591{dev: dev}
 
 	// Read the device ID from the TSL2591. It should be 0x50.
 	deviceID, err := tsl.readU8(RegisterDeviceID)
@@ -71,6 +289,11 @@ func NewTSL2591(opts *Opts) (*TSL2591, error) {
 		return nil, UnexpectedDeviceIDError{Actual: deviceID, Expected: DeviceID}
 	}
 
+	// Initialize the shadow of the control register
+	if tsl.control, err = tsl.readU8(RegisterControl); err != nil {
+		return nil, fmt.Errorf("unable to read sensor control: %w", err)
+	}
+
 	if err = tsl.SetGain(opts.Gain); err != nil {
 		return nil, fmt.Errorf("unable to set gain: %w", err)
 	}
@@ -86,96 +309,361 @@ func NewTSL2591(opts *Opts) (*TSL2591, error) {
 	return tsl, nil
 }
 
-// Enable enables the TSL2591 chip
+// String implements conn.Resource
+func (tsl *TSL2591) String() string {
+	if dev, ok := tsl.dev.(fmt.Stringer); ok {
+		return "TSL2591{" + dev.String() + "}"
+	}
+	return "TSL2591"
+}
+
+// Halt implements conn.Resource. It stops all continuous sensing and disables the chip.
+func (tsl *TSL2591) Halt() error {
+	tsl.mu.Lock()
+	if tsl.haltCancel != nil {
+		tsl.haltCancel()
+		tsl.haltCtx, tsl.haltCancel = nil, nil
+	}
+	tsl.mu.Unlock()
+
+	tsl.sensing.Wait()
+	return tsl.Disable()
+}
+
+// Close stops all continuous sensing, disables the chip and closes the I2C bus
+// if it was opened by NewTSL2591. A connection provided to NewTSL2591WithConn is not closed.
+// Afterwards, all methods accessing the sensor return ErrClosed.
+func (tsl *TSL2591) Close() error {
+	haltErr := tsl.Halt()
+
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	tsl.closed = true
+	if tsl.bus != nil {
+		if err := tsl.bus.Close(); err != nil {
+			return fmt.Errorf("failed to close I2C bus: %w", err)
+		}
+		tsl.bus = nil
+	}
+	return haltErr
+}
+
+// EnableOptions selects the optional functions enabled by EnableWithOptions.
+// Power and the ALS are always enabled.
+type EnableOptions struct {
+	// ALSInterrupt permits ALS interrupts, subject to the persist filter (AIEN)
+	ALSInterrupt bool
+
+	// NoPersistInterrupt permits no-persist interrupts, bypassing the persist filter (NPIEN)
+	NoPersistInterrupt bool
+
+	// SleepAfterInterrupt puts the sensor to sleep at the end of the ALS cycle
+	// in which an interrupt was generated (SAI). See SetSleepAfterInterrupt.
+	SleepAfterInterrupt bool
+}
+
+// register returns the value of the enable register
+func (o EnableOptions) register() byte {
+	enable := EnablePowerOn | EnableAEN
+	if o.ALSInterrupt {
+		enable |= EnableAIEN
+	}
+	if o.NoPersistInterrupt {
+		enable |= EnableNPIEN
+	}
+	if o.SleepAfterInterrupt {
+		enable |= EnableSAI
+	}
+	return enable
+}
+
+// Enable enables power and the ALS of the TSL2591 chip.
+// Interrupts stay disabled, unless enabled before with EnableWithOptions.
 func (tsl *TSL2591) Enable() error {
-	err := tsl.writeU8(RegisterEnable, EnablePowerOn|EnableAEN|EnableAIEN|EnableNPIEN)
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.enable()
+}
+
+// EnableWithOptions enables power, the ALS and the provided functions of the TSL2591 chip.
+// The options are kept for Enable and for re-enabling the chip after a reset or recovery.
+func (tsl *TSL2591) EnableWithOptions(opts EnableOptions) error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	prev := tsl.enableOpts
+	tsl.enableOpts = opts
+	if err := tsl.enable(); err != nil {
+		tsl.enableOpts = prev
+		return err
+	}
+	return nil
+}
+
+// enable enables the TSL2591 chip with the stored options
+func (tsl *TSL2591) enable() error {
+	err := tsl.writeU8(RegisterEnable, tsl.enableOpts.register())
 	if err != nil {
 		return fmt.Errorf("failed to enable sensor: %w", err)
 	}
+	if !tsl.enabled {
+		// Powering on starts a new ALS cycle
+		tsl.lastRead = time.Time{}
+	}
+	tsl.enabled = true
 	return nil
 }
 
 // Disable disables the TSL2591 chip
 func (tsl *TSL2591) Disable() error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
 	err := tsl.writeU8(RegisterEnable, EnablePowerOff)
 	if err != nil {
 		return fmt.Errorf("failed to disable sensor: %w", err)
 	}
+	tsl.enabled = false
+	return nil
+}
+
+// IsEnabled returns whether the chip is powered on with the ALS enabled.
+// The tracked state is verified against the enable register, so false is returned
+// if the chip was reset or powered down externally after calling Enable.
+func (tsl *TSL2591) IsEnabled() (bool, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.isEnabled()
+}
+
+// isEnabled returns whether the chip is powered on with the ALS enabled
+func (tsl *TSL2591) isEnabled() (bool, error) {
+	if !tsl.enabled {
+		return false, nil
+	}
+	enable, err := tsl.readU8(RegisterEnable)
+	if err != nil {
+		return false, fmt.Errorf("failed to read sensor enable: %w", err)
+	}
+	return enable&(EnablePowerOn|EnableAEN) == EnablePowerOn|EnableAEN, nil
+}
+
+// SetSleepAfterInterrupt enables or disables the sleep after interrupt (SAI) mode.
+// When enabled, the sensor goes to sleep at the end of the ALS cycle in which
+// an interrupt was generated. It resumes after the interrupt is cleared.
+func (tsl *TSL2591) SetSleepAfterInterrupt(enabled bool) error {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	// Get enable
+	enable, err := tsl.readU8(RegisterEnable)
+	if err != nil {
+		return fmt.Errorf("failed to read current sensor enable: %w", err)
+	}
+
+	// Update enable
+	if enabled {
+		enable |= EnableSAI
+	} else {
+		enable &^= EnableSAI
+	}
+
+	// Write enable
+	if err = tsl.writeU8(RegisterEnable, enable); err != nil {
+		return fmt.Errorf("failed to write sensor enable: %w", err)
+	}
+	tsl.enableOpts.SleepAfterInterrupt = enabled
 	return nil
 }
 
 // SetGain sets TSL2591 gain
 func (tsl *TSL2591) SetGain(gain Gain) error {
-	// Get control
-	control, err := tsl.readU8(RegisterControl)
-	if err != nil {
-		return fmt.Errorf("failed to read current sensor control: %w", err)
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.setGain(gain)
+}
+
+// GetGain returns the current TSL2591 gain
+func (tsl *TSL2591) GetGain() Gain {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.gain
+}
+
+// setGain sets TSL2591 gain
+func (tsl *TSL2591) setGain(gain Gain) error {
+	if !gain.valid() {
+		return fmt.Errorf("%w: 0x%02x", ErrInvalidGain, byte(gain))
 	}
 
 	// Update control
+	control := tsl.control
 	control &= 0b11001111
 	control |= byte(gain)
 
 	// Write control
-	if err = tsl.writeU8(RegisterControl, control); err != nil {
+	if err := tsl.writeU8(RegisterControl, control); err != nil {
 		return fmt.Errorf("failed to write sensor control: %w", err)
 	}
+	tsl.control = control
 	tsl.gain = gain
+	tsl.stale = true
 	return nil
 }
 
 // SetTiming sets TSL2591 timing. Chip is enabled, timing set, then disabled
 func (tsl *TSL2591) SetTiming(timing IntegrationTime) error {
-	// Get control
-	control, err := tsl.readU8(RegisterControl)
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.setTiming(timing)
+}
+
+// GetTiming returns the current TSL2591 timing
+func (tsl *TSL2591) GetTiming() IntegrationTime {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	return tsl.timing
+}
+
+// SetTimingDuration sets TSL2591 timing from a duration between 100ms and 600ms
+// in steps of 100ms. Returns ErrInvalidTiming for unsupported durations.
+func (tsl *TSL2591) SetTimingDuration(d time.Duration) error {
+	timing, err := integrationTimeFromDuration(d)
 	if err != nil {
-		return fmt.Errorf("failed to read current sensor control: %w", err)
+		return err
+	}
+	return tsl.SetTiming(timing)
+}
+
+// setTiming sets TSL2591 timing
+func (tsl *TSL2591) setTiming(timing IntegrationTime) error {
+	if !timing.valid() {
+		return fmt.Errorf("%w: 0x%02x", ErrInvalidTiming, byte(timing))
 	}
 
 	// Update control
+	control := tsl.control
 	control &= 0b11111000
 	control |= byte(timing)
 
 	// Write control
-	if err = tsl.writeU8(RegisterControl, control); err != nil {
+	if err := tsl.writeU8(RegisterControl, control); err != nil {
 		return fmt.Errorf("failed to write sensor control: %w", err)
 	}
+	tsl.control = control
 	tsl.timing = timing
+	tsl.stale = true
 	return nil
 }
 
-// RawLuminosity reads from the sensor
+// RawLuminosity reads from the sensor.
+// If auto ranging is enabled, the settings are adjusted and the channels are re-read as needed.
+// The dark offset is subtracted, see CalibrateDarkOffset.
 func (tsl *TSL2591) RawLuminosity() (uint16, uint16, error) {
-	// The first value is IR + visible luminosity (channel 0)
-	// and the second is the IR only (channel 1). Both values
-	// are 16-bit unsigned numbers (0-65535)
-	c0, err := tsl.readU16(RegisterChan0Low)
+	return tsl.RawLuminosityContext(context.Background())
+}
+
+// RawLuminosityContext is RawLuminosity which honors cancellation and deadlines of the context
+func (tsl *TSL2591) RawLuminosityContext(ctx context.Context) (uint16, uint16, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	c0, c1, err := tsl.rawLuminosity(ctx)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to read channel 0 of raw luminosity: %w", err)
+		return 0, 0, err
 	}
+	c0, c1 = tsl.correctDark(c0, c1)
+	return c0, c1, nil
+}
 
-	c1, err := tsl.readU16(RegisterChan1Low)
+// rawLuminosity reads from the sensor and applies auto ranging
+func (tsl *TSL2591) rawLuminosity(ctx context.Context) (uint16, uint16, error) {
+	c0, c1, err := tsl.readChannels(ctx)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to read channel 1 of raw luminosity: %w", err)
+		return 0, 0, tsl.handleReadFailure(ctx, err)
+	}
+	tsl.failures = 0
+	if tsl.autoRange != nil {
+		return tsl.adjustRange(ctx, c0, c1)
+	}
+	return c0, c1, nil
+}
+
+// readChannels reads both channels from the sensor
+func (tsl *TSL2591) readChannels(ctx context.Context) (uint16, uint16, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+	if !tsl.enabled {
+		return 0, 0, ErrNotEnabled
 	}
 
+	// After the ALS cycle was (re)started, the channels only hold a new conversion
+	// once AVALID is set. Checked before reading, so a conversion which completes
+	// in between isn't mistaken for the data read.
+	validAfterStart := false
+	if tsl.lastRead.IsZero() {
+		status, err := tsl.status()
+		if err != nil {
+			return 0, 0, err
+		}
+		validAfterStart = status.ALSValid
+	}
+
+	// The first value is IR + visible luminosity (channel 0)
+	// and the second is the IR only (channel 1). Both values
+	// are 16-bit unsigned numbers (0-65535). Both channels are
+	// read in a single transaction, so they belong to the same
+	// integration cycle.
+	data, err := tsl.readBlock(RegisterChan0Low, 4)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read channels of raw luminosity: %w", err)
+	}
+	c0 := binary.LittleEndian.Uint16(data[0:2])
+	c1 := binary.LittleEndian.Uint16(data[2:4])
+	tsl.metrics.reads.Add(1)
+
+	// Otherwise, the channels hold a new conversion if they changed
+	// or a full ALS cycle passed since the previous read
+	now := time.Now()
+	if tsl.lastRead.IsZero() {
+		tsl.fresh = validAfterStart
+	} else {
+		tsl.fresh = c0 != tsl.lastC0 || c1 != tsl.lastC1 || now.Sub(tsl.lastRead) >= cycleDuration(tsl.timing)
+	}
+	tsl.lastRead, tsl.lastC0, tsl.lastC1 = now, c0, c1
+	if err := tsl.checkStuck(ctx, c0, c1); err != nil {
+		return 0, 0, err
+	}
 	return c0, c1, nil
 }
 
 // FullSpectrum returns the full spectrum value
 func (tsl *TSL2591) FullSpectrum() (uint32, error) {
+	return tsl.FullSpectrumContext(context.Background())
+}
+
+// FullSpectrumContext is FullSpectrum which honors cancellation and deadlines of the context
+func (tsl *TSL2591) FullSpectrumContext(ctx context.Context) (uint32, error) {
 	// Full spectrum (IR + visible) light and return its value
 	// as a 32-bit unsigned number
-	c0, c1, err := tsl.RawLuminosity()
+	c0, c1, err := tsl.RawLuminosityContext(ctx)
 	if err != nil {
 		return 0, err
 	}
-	return uint32(c1)<<16 | uint32(c0), nil
+	return fullSpectrum(c0, c1), nil
+}
+
+// fullSpectrum combines the raw channel counts into the full spectrum value
+func fullSpectrum(c0, c1 uint16) uint32 {
+	return uint32(c1)<<16 | uint32(c0)
 }
 
 // Infrared returns infrared value
 func (tsl *TSL2591) Infrared() (uint16, error) {
-	_, c1, err := tsl.RawLuminosity()
+	return tsl.InfraredContext(context.Background())
+}
+
+// InfraredContext is Infrared which honors cancellation and deadlines of the context
+func (tsl *TSL2591) InfraredContext(ctx context.Context) (uint16, error) {
+	_, c1, err := tsl.RawLuminosityContext(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -184,53 +672,74 @@ func (tsl *TSL2591) Infrared() (uint16, error) {
 
 // Visible returns visible value
 func (tsl *TSL2591) Visible() (uint32, error) {
-	c0, c1, err := tsl.RawLuminosity()
+	return tsl.VisibleContext(context.Background())
+}
+
+// VisibleContext is Visible which honors cancellation and deadlines of the context
+func (tsl *TSL2591) VisibleContext(ctx context.Context) (uint32, error) {
+	c0, c1, err := tsl.RawLuminosityContext(ctx)
 	if err != nil {
 		return 0, err
 	}
-	full := uint32(c1)<<16 | uint32(c0)
-	return full - uint32(c1), nil
+	return visible(c0, c1), nil
 }
 
-// Lux calculates a lux value from both the infrared and visible channels
-func (tsl *TSL2591) Lux() (float64, error) {
+// visible derives the visible value from the raw channel counts
+func visible(c0, c1 uint16) uint32 {
+	return fullSpectrum(c0, c1) - uint32(c1)
+}
+
+// IRRatio returns the ratio of infrared (channel 1) to full spectrum (channel 0) counts,
+// which characterizes the light source. Returns 0 if channel 0 reads zero.
+func (tsl *TSL2591) IRRatio() (float64, error) {
 	c0, c1, err := tsl.RawLuminosity()
 	if err != nil {
 		return 0, err
 	}
+	if c0 == 0 {
+		return 0, nil
+	}
+	return float64(c1) / float64(c0), nil
+}
 
-	// Compute the atime in milliseconds
-	atime := 100*uint16(tsl.timing) + 100
+// NormalizedCounts returns the counts of channel 0 and channel 1 divided by the
+// integration time in milliseconds and the gain multiplier (counts/ms/x).
+// This makes readings taken with different settings directly comparable.
+func (tsl *TSL2591) NormalizedCounts() (float64, float64, error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
 
-	// Set the maximum sensor counts based on the integration time (atime) setting
-	var maxCounts uint16
-	if tsl.timing == IntegrationTime100MS {
-		maxCounts = MaxCount100ms
-	} else {
-		maxCounts = MaxCount
+	c0, c1, err := tsl.rawLuminosity(context.Background())
+	if err != nil {
+		return 0, 0, err
 	}
+	c0, c1 = tsl.correctDark(c0, c1)
+	scale := tsl.countScale()
+	return float64(c0) / scale, float64(c1) / scale, nil
+}
 
-	// Handle overflow.
-	if c0 >= maxCounts || c1 >= maxCounts {
-		return 0, ErrOverflow
-	}
+// Lux calculates a lux value from both the infrared and visible channels
+func (tsl *TSL2591) Lux() (float64, error) {
+	return tsl.LuxContext(context.Background())
+}
 
-	// Calculate lux
-	var again uint16
-	switch tsl.gain {
-	case GainLow:
-		again = 1
-	case GainMed:
-		again = 25
-	case GainHigh:
-		again = 428
-	case GainMax:
-		again = 9876
+// LuxContext is Lux which honors cancellation and deadlines of the context
+func (tsl *TSL2591) LuxContext(ctx context.Context) (float64, error) {
+	m, err := tsl.MeasureContext(ctx)
+	if err != nil {
+		return 0, err
 	}
+	return m.Lux, nil
+}
 
-	cpl := float64((atime * again)) / LuxDF
-	lux1 := (float64(c0) - (LuxCoefB * float64(c1))) / cpl
-	lux2 := ((LuxCoefC * float64(c0)) - (LuxCoefD * float64(c1))) / cpl
-
-	return math.Max(lux1, lux2), nil
+// sleepContext sleeps for the provided duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
@@ -0,0 +1,156 @@
+package tsl2591
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+// setupOps returns the transactions of NewTSL2591WithConn for the provided settings
+func setupOps(gain Gain, timing IntegrationTime) []i2ctest.IO {
+	return []i2ctest.IO{
+		{Addr: Addr, W: []byte{CommandBit | RegisterDeviceID}, R: []byte{DeviceID}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterControl}, R: []byte{0x00}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(gain)}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(gain) | byte(timing)}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterEnable, EnablePowerOn | EnableAEN}},
+	}
+}
+
+// readOps returns the transactions of reading the channels.
+// If status is set, the status register is read first, which happens
+// on the first read after the ALS cycle was started.
+func readOps(c0, c1 uint16, status bool) []i2ctest.IO {
+	var ops []i2ctest.IO
+	if status {
+		ops = append(ops, i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterDeviceStatus}, R: []byte{StatusAVALID}})
+	}
+	return append(ops, i2ctest.IO{
+		Addr: Addr,
+		W:    []byte{CommandBit | RegisterChan0Low},
+		R:    []byte{byte(c0), byte(c0 >> 8), byte(c1), byte(c1 >> 8)},
+	})
+}
+
+// newTestSensor sets up a sensor on a scripted bus, which plays back the setup
+// for the provided options followed by ops. The bus verifies all ops were
+// played back at the end of the test.
+func newTestSensor(t *testing.T, opts *Opts, ops ...i2ctest.IO) *TSL2591 {
+	t.Helper()
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	bus := &i2ctest.Playback{Ops: append(setupOps(opts.Gain, opts.Timing), ops...), DontPanic: true}
+	t.Cleanup(func() {
+		if err := bus.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tsl, err := NewTSL2591WithConn(&i2c.Dev{Addr: Addr, Bus: bus}, opts)
+	if err != nil {
+		t.Fatalf("failed to set up sensor: %v", err)
+	}
+	return tsl
+}
+
+func TestNewTSL2591WithConnUnexpectedDeviceID(t *testing.T) {
+	bus := &i2ctest.Playback{Ops: []i2ctest.IO{
+		{Addr: Addr, W: []byte{CommandBit | RegisterDeviceID}, R: []byte{0x42}},
+	}, DontPanic: true}
+	_, err := NewTSL2591WithConn(&i2c.Dev{Addr: Addr, Bus: bus}, nil)
+	var idErr UnexpectedDeviceIDError
+	if !errors.As(err, &idErr) || idErr.Actual != 0x42 {
+		t.Fatalf("expected UnexpectedDeviceIDError for 0x42, got %v", err)
+	}
+}
+
+func TestNewTSL2591WithConnInvalidConfig(t *testing.T) {
+	opts := DefaultOptions()
+	opts.SmoothingAlpha = 2
+	_, err := NewTSL2591WithConn(&i2c.Dev{Addr: Addr, Bus: &i2ctest.Playback{DontPanic: true}}, opts)
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestSetGainAndTiming(t *testing.T) {
+	// The control register is shadowed, so it's written without reading it first
+	tsl := newTestSensor(t, nil,
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainHigh) | byte(IntegrationTime100MS)}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterControl, byte(GainHigh) | byte(IntegrationTime300MS)}},
+	)
+
+	if err := tsl.SetGain(GainHigh); err != nil {
+		t.Fatalf("SetGain failed: %v", err)
+	}
+	if err := tsl.SetTiming(IntegrationTime300MS); err != nil {
+		t.Fatalf("SetTiming failed: %v", err)
+	}
+	if tsl.gain != GainHigh {
+		t.Errorf("expected gain %v, got %v", GainHigh, tsl.gain)
+	}
+	if tsl.timing != IntegrationTime300MS {
+		t.Errorf("expected timing %v, got %v", IntegrationTime300MS, tsl.timing)
+	}
+}
+
+func TestSetGainAndTimingInvalid(t *testing.T) {
+	// Invalid values are rejected without touching the bus
+	tsl := newTestSensor(t, nil)
+
+	if err := tsl.SetGain(Gain(0x40)); !errors.Is(err, ErrInvalidGain) {
+		t.Errorf("expected ErrInvalidGain, got %v", err)
+	}
+	if err := tsl.SetTiming(IntegrationTime(0x06)); !errors.Is(err, ErrInvalidTiming) {
+		t.Errorf("expected ErrInvalidTiming, got %v", err)
+	}
+	if tsl.gain != GainMed {
+		t.Errorf("expected gain to stay %v, got %v", GainMed, tsl.gain)
+	}
+}
+
+func TestLux(t *testing.T) {
+	tsl := newTestSensor(t, nil, readOps(1000, 200, true)...)
+
+	lux, err := tsl.Lux()
+	if err != nil {
+		t.Fatalf("Lux failed: %v", err)
+	}
+
+	// 100ms at medium gain (25x)
+	cpl := 100 * 25 / LuxDF
+	expected := (1000 - LuxCoefB*200) / cpl
+	if math.Abs(lux-expected) > 1e-9 {
+		t.Errorf("expected %f lux, got %f", expected, lux)
+	}
+}
+
+func TestLuxOverflow(t *testing.T) {
+	tsl := newTestSensor(t, nil, readOps(MaxCount100ms, 200, true)...)
+
+	_, err := tsl.Lux()
+	var satErr SaturationError
+	if !errors.Is(err, ErrOverflow) || !errors.As(err, &satErr) {
+		t.Fatalf("expected SaturationError, got %v", err)
+	}
+	if satErr.Channel != FullSpectrum {
+		t.Errorf("expected channel %d to be saturated, got %d", FullSpectrum, satErr.Channel)
+	}
+}
+
+func TestLuxNotEnabled(t *testing.T) {
+	tsl := newTestSensor(t, nil,
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterEnable, EnablePowerOff}},
+	)
+
+	if err := tsl.Disable(); err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+	if _, err := tsl.Lux(); !errors.Is(err, ErrNotEnabled) {
+		t.Fatalf("expected ErrNotEnabled, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Package tsl2591mock provides a fake TSL2591 sensor with scriptable
+// readings and injectable errors, so applications can be tested without an I2C bus.
+package tsl2591mock
+
+import (
+	"sync"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+)
+
+// Reading is a single scripted reading of the fake sensor
+type Reading struct {
+	// Chan0 is the raw count of channel 0 (IR + visible)
+	Chan0 uint16
+
+	// Chan1 is the raw count of channel 1 (IR only)
+	Chan1 uint16
+
+	// Lux is returned as is by Lux
+	Lux float64
+
+	// Err is returned by the read method consuming this reading, if set
+	Err error
+}
+
+var _ tsl2591.LightSensor = (*Sensor)(nil)
+
+// Sensor is a fake TSL2591 sensor. Each read method consumes the next scripted reading.
+// Once all readings are consumed, the last one is repeated.
+// Sensor is safe for concurrent use.
+type Sensor struct {
+	mu         sync.Mutex
+	readings   []Reading
+	last       Reading
+	enabled    bool
+	enableErr  error
+	disableErr error
+}
+
+// New returns a fake sensor which is enabled and returns the provided readings in order
+func New(readings ...Reading) *Sensor {
+	return &Sensor{readings: readings, enabled: true}
+}
+
+// Push appends readings to the script
+func (s *Sensor) Push(readings ...Reading) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readings = append(s.readings, readings...)
+}
+
+// SetEnableError sets the error returned by Enable. Use nil to clear it.
+func (s *Sensor) SetEnableError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enableErr = err
+}
+
+// SetDisableError sets the error returned by Disable. Use nil to clear it.
+func (s *Sensor) SetDisableError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disableErr = err
+}
+
+// Enabled returns whether the fake sensor is enabled
+func (s *Sensor) Enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enabled
+}
+
+// Enable enables the fake sensor
+func (s *Sensor) Enable() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.enableErr != nil {
+		return s.enableErr
+	}
+	s.enabled = true
+	return nil
+}
+
+// Disable disables the fake sensor
+func (s *Sensor) Disable() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.disableErr != nil {
+		return s.disableErr
+	}
+	s.enabled = false
+	return nil
+}
+
+// RawLuminosity returns the raw channel counts of the next reading
+func (s *Sensor) RawLuminosity() (uint16, uint16, error) {
+	r := s.next()
+	if r.Err != nil {
+		return 0, 0, r.Err
+	}
+	return r.Chan0, r.Chan1, nil
+}
+
+// FullSpectrum returns the full spectrum value of the next reading
+func (s *Sensor) FullSpectrum() (uint32, error) {
+	c0, c1, err := s.RawLuminosity()
+	if err != nil {
+		return 0, err
+	}
+	return uint32(c1)<<16 | uint32(c0), nil
+}
+
+// Infrared returns the infrared value of the next reading
+func (s *Sensor) Infrared() (uint16, error) {
+	_, c1, err := s.RawLuminosity()
+	if err != nil {
+		return 0, err
+	}
+	return c1, nil
+}
+
+// Visible returns the visible value of the next reading
+func (s *Sensor) Visible() (uint32, error) {
+	c0, c1, err := s.RawLuminosity()
+	if err != nil {
+		return 0, err
+	}
+	full := uint32(c1)<<16 | uint32(c0)
+	return full - uint32(c1), nil
+}
+
+// Lux returns the lux value of the next reading
+func (s *Sensor) Lux() (float64, error) {
+	r := s.next()
+	if r.Err != nil {
+		return 0, r.Err
+	}
+	return r.Lux, nil
+}
+
+// next consumes the next scripted reading
+func (s *Sensor) next() Reading {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.readings) > 0 {
+		s.last = s.readings[0]
+		s.readings = s.readings[1:]
+	}
+	return s.last
+}
@@ -0,0 +1,185 @@
+package tsl2591
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// edgePollTimeout bounds a single wait for an interrupt edge, so the context is checked regularly
+const edgePollTimeout = 500 * time.Millisecond
+
+// WaitForLuxAbove blocks until lux rises above the threshold or the context is done.
+// See WaitForLuxBelow for details.
+func (tsl *TSL2591) WaitForLuxAbove(ctx context.Context, threshold float64) (Measurement, error) {
+	return tsl.waitForLux(ctx, threshold, true)
+}
+
+// WaitForLuxBelow blocks until lux falls below the threshold or the context is done.
+// If Opts.InterruptPin is set and auto ranging is disabled, the ALS interrupt thresholds
+// are programmed, ALS interrupts are enabled and the INT pin is awaited for at most
+// an ALS cycle before measuring again. As the thresholds apply to channel 0, IR light
+// can keep the interrupt from firing although lux passed the threshold.
+// The thresholds, persist filter and enabled interrupts are restored afterwards.
+// Otherwise the sensor is polled once per integration cycle.
+func (tsl *TSL2591) WaitForLuxBelow(ctx context.Context, threshold float64) (Measurement, error) {
+	return tsl.waitForLux(ctx, threshold, false)
+}
+
+// waitForLux blocks until lux passes the threshold in the provided direction
+func (tsl *TSL2591) waitForLux(ctx context.Context, threshold float64, above bool) (Measurement, error) {
+	met := func(m Measurement) bool {
+		if above {
+			return m.Lux > threshold
+		}
+		return m.Lux < threshold
+	}
+
+	tsl.mu.Lock()
+	useInterrupt := tsl.opts.InterruptPin != nil && tsl.autoRange == nil
+	tsl.mu.Unlock()
+	if useInterrupt {
+		restore, err := tsl.armLuxInterrupt(threshold, above)
+		if err != nil {
+			return Measurement{}, err
+		}
+		defer restore()
+	}
+
+	for {
+		m, err := tsl.MeasureContext(ctx)
+		if err != nil {
+			return Measurement{}, err
+		}
+		if met(m) {
+			return m, nil
+		}
+
+		if useInterrupt {
+			if err = tsl.waitForInterrupt(ctx, cycleDuration(m.Timing)); err != nil {
+				return Measurement{}, err
+			}
+		} else if err = sleepContext(ctx, m.Timing.Duration()); err != nil {
+			return Measurement{}, err
+		}
+	}
+}
+
+// armLuxInterrupt programs the ALS interrupt thresholds for the lux threshold
+// and enables ALS interrupts if needed. Returns a function restoring the previous
+// thresholds, persist filter and enabled interrupts.
+func (tsl *TSL2591) armLuxInterrupt(threshold float64, above bool) (func(), error) {
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+
+	if err := configurePin(tsl.opts.InterruptPin); err != nil {
+		return nil, fmt.Errorf("failed to configure interrupt pin: %w", err)
+	}
+
+	// Save current configuration
+	prevLow, err := tsl.readU16(RegisterThresholdAILTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ALS low threshold: %w", err)
+	}
+	prevHigh, err := tsl.readU16(RegisterThresholdAIHTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ALS high threshold: %w", err)
+	}
+	prevPersist, err := tsl.readU8(RegisterPersistFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read persist filter: %w", err)
+	}
+
+	// Program thresholds. Channel 0 is compared, so IR is ignored in the conversion.
+	// This might trigger early, which is handled by confirming with a measurement,
+	// or not at all, which is handled by bounding the wait for the interrupt.
+	counts := tsl.luxToCounts(threshold)
+	low, high := uint16(0), counts
+	if !above {
+		low, high = counts, math.MaxUint16
+	}
+	if err = tsl.writeU16(RegisterThresholdAILTL, low); err != nil {
+		return nil, fmt.Errorf("failed to write ALS low threshold: %w", err)
+	}
+	if err = tsl.writeU16(RegisterThresholdAIHTL, high); err != nil {
+		return nil, fmt.Errorf("failed to write ALS high threshold: %w", err)
+	}
+	if err = tsl.writeU8(RegisterPersistFilter, prevPersist&0b11110000|byte(PersistAny)); err != nil {
+		return nil, fmt.Errorf("failed to write persist filter: %w", err)
+	}
+	if err = tsl.writeSpecial(ClearALSInt); err != nil {
+		return nil, fmt.Errorf("failed to clear ALS interrupt: %w", err)
+	}
+	enableInterrupt := !tsl.enableOpts.ALSInterrupt
+	if enableInterrupt {
+		tsl.enableOpts.ALSInterrupt = true
+		if tsl.enabled {
+			if err = tsl.enable(); err != nil {
+				tsl.enableOpts.ALSInterrupt = false
+				return nil, fmt.Errorf("failed to enable ALS interrupt: %w", err)
+			}
+		}
+	}
+
+	restore := func() {
+		tsl.mu.Lock()
+		defer tsl.mu.Unlock()
+		if enableInterrupt {
+			tsl.enableOpts.ALSInterrupt = false
+			if tsl.enabled {
+				_ = tsl.enable()
+			}
+		}
+		_ = tsl.writeU16(RegisterThresholdAILTL, prevLow)
+		_ = tsl.writeU16(RegisterThresholdAIHTL, prevHigh)
+		_ = tsl.writeU8(RegisterPersistFilter, prevPersist)
+		_ = tsl.writeSpecial(ClearALSInt)
+	}
+	return restore, nil
+}
+
+// waitForInterrupt waits at most timeout for a falling edge on the interrupt pin
+// and acknowledges the interrupt if one occurred
+func (tsl *TSL2591) waitForInterrupt(ctx context.Context, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil
+		}
+		if wait > edgePollTimeout {
+			wait = edgePollTimeout
+		}
+		if tsl.opts.InterruptPin.WaitForEdge(wait) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	tsl.mu.Lock()
+	defer tsl.mu.Unlock()
+	if err := tsl.writeSpecial(ClearALSInt); err != nil {
+		return fmt.Errorf("failed to clear ALS interrupt: %w", err)
+	}
+	return nil
+}
+
+// luxToCounts converts lux to raw channel 0 counts with the current settings, assuming no IR.
+// The dark offset is added, as the thresholds are compared with the uncorrected counts.
+func (tsl *TSL2591) luxToCounts(lux float64) uint16 {
+	if tsl.luxCalibration.Scale != 0 {
+		lux = (lux - tsl.luxCalibration.Offset) / tsl.luxCalibration.Scale
+	}
+	scale := tsl.countScale()
+	counts := lux*scale/tsl.opts.LuxCoefficients.DF + tsl.darkOffset.Chan0*scale
+	switch {
+	case counts <= 0:
+		return 0
+	case counts >= math.MaxUint16:
+		return math.MaxUint16
+	}
+	return uint16(counts)
+}
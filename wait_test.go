@@ -0,0 +1,84 @@
+package tsl2591
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/i2c/i2ctest"
+)
+
+// silentPin is a Pin on which no edge ever occurs
+type silentPin struct{}
+
+func (silentPin) WaitForEdge(timeout time.Duration) bool {
+	time.Sleep(timeout)
+	return false
+}
+
+func TestLuxToCounts(t *testing.T) {
+	// 100ms at medium gain (25x) results in 2500/408 counts per lux
+	tests := []struct {
+		name        string
+		lux         float64
+		dark        DarkOffset
+		calibration LuxCalibration
+		expected    uint16
+	}{
+		{name: "plain", lux: 50, expected: 306},
+		{name: "dark offset", lux: 50, dark: DarkOffset{Chan0: 0.01, Chan1: 1}, expected: 331},
+		{name: "calibration", lux: 50, calibration: LuxCalibration{Scale: 2, Offset: 10}, expected: 122},
+		{name: "negative", lux: -10, expected: 0},
+		{name: "clamped", lux: 1e6, expected: 0xffff},
+	}
+	for _, tt := range tests {
+		tsl := &TSL2591{
+			gain:           GainMed,
+			timing:         IntegrationTime100MS,
+			opts:           Opts{LuxCoefficients: DefaultLuxCoefficients()},
+			darkOffset:     tt.dark,
+			luxCalibration: tt.calibration,
+		}
+		if counts := tsl.luxToCounts(tt.lux); counts != tt.expected {
+			t.Errorf("%s: expected %d counts, got %d", tt.name, tt.expected, counts)
+		}
+	}
+}
+
+func TestWaitForLuxBelowHighIR(t *testing.T) {
+	// With a lot of IR, lux is below 50 while channel 0 stays above the low threshold
+	// of 306 counts. The interrupt never fires, so the sensor is measured again after a cycle.
+	enable := EnablePowerOn | EnableAEN
+	ops := []i2ctest.IO{
+		{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAILTL}, R: []byte{0x00, 0x00}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAIHTL}, R: []byte{0xff, 0xff}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterPersistFilter}, R: []byte{0x00}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAILTL, 0x32, 0x01}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAIHTL, 0xff, 0xff}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterPersistFilter, byte(PersistAny)}},
+		{Addr: Addr, W: []byte{ClearALSInt}},
+		{Addr: Addr, W: []byte{CommandBit | RegisterEnable, enable | EnableAIEN}},
+	}
+	ops = append(ops, readOps(2000, 200, true)...)
+	ops = append(ops, readOps(1000, 500, false)...)
+	ops = append(ops,
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterEnable, enable}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAILTL, 0x00, 0x00}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterThresholdAIHTL, 0xff, 0xff}},
+		i2ctest.IO{Addr: Addr, W: []byte{CommandBit | RegisterPersistFilter, 0x00}},
+		i2ctest.IO{Addr: Addr, W: []byte{ClearALSInt}},
+	)
+	opts := DefaultOptions()
+	opts.InterruptPin = silentPin{}
+	tsl := newTestSensor(t, opts, ops...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	m, err := tsl.WaitForLuxBelow(ctx, 50)
+	if err != nil {
+		t.Fatalf("WaitForLuxBelow failed: %v", err)
+	}
+	if m.Lux >= 50 || m.Chan0 != 1000 {
+		t.Errorf("expected reading with chan0 1000 below 50 lux, got %f lux and chan0 %d", m.Lux, m.Chan0)
+	}
+}
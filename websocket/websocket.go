@@ -0,0 +1,102 @@
+// Package websocket streams TSL2591 measurements to WebSocket clients.
+//
+// Example:
+//
+//	source, stop := sensor.SenseContinuous(time.Second)
+//	defer stop()
+//	broadcaster := tsl2591.NewBroadcaster(source)
+//	http.Handle("/stream", websocket.NewHandler(broadcaster))
+//	log.Fatal(http.ListenAndServe(":8080", nil))
+package websocket
+
+import (
+	"net/http"
+	"time"
+
+	tsl2591 "github.com/JenswBE/golang-tsl2591"
+	"github.com/gorilla/websocket"
+)
+
+// DefaultBuffer is the subscription buffer used if Handler.Buffer is zero
+const DefaultBuffer = 16
+
+// writeTimeout is the maximum time to write a single message
+const writeTimeout = 10 * time.Second
+
+// Message is the JSON message sent for every measurement.
+// Either the measurement fields or Error is set.
+type Message struct {
+	*tsl2591.Measurement
+	Error string `json:"error,omitempty"`
+}
+
+// Handler upgrades requests to WebSocket connections
+// and streams all measurements of the broadcaster as JSON messages
+type Handler struct {
+	// Broadcaster provides the measurements
+	Broadcaster *tsl2591.Broadcaster
+
+	// Buffer is the number of measurements buffered per client
+	Buffer int
+
+	// Upgrader is used to upgrade the requests, e.g. to configure CheckOrigin
+	Upgrader websocket.Upgrader
+}
+
+// NewHandler creates a new handler with the default settings.
+// Slow clients miss the oldest measurements instead of blocking the others.
+func NewHandler(broadcaster *tsl2591.Broadcaster) *Handler {
+	return &Handler{Broadcaster: broadcaster, Buffer: DefaultBuffer}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade already replied with an error
+		return
+	}
+	defer conn.Close()
+
+	buffer := h.Buffer
+	if buffer == 0 {
+		buffer = DefaultBuffer
+	}
+	measurements, unsubscribe := h.Broadcaster.Subscribe(buffer, tsl2591.BackpressureDropOldest)
+	defer unsubscribe()
+
+	// Read and discard client messages to handle control frames and detect disconnects
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-r.Context().Done():
+			return
+		case m, ok := <-measurements:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+					time.Now().Add(writeTimeout))
+				return
+			}
+			msg := Message{Measurement: &m}
+			if m.Err != nil {
+				msg = Message{Error: m.Err.Error()}
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+	}
+}